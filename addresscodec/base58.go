@@ -0,0 +1,111 @@
+package addresscodec
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"errors"
+	"fmt"
+	"math/big"
+)
+
+// Alphabet is the XRP Ledger's base58 alphabet. It differs from Bitcoin's so
+// that classic addresses start with 'r'.
+const Alphabet = "rpshnaf39wBUDNEGHJKLM4PQRST7VWXYZ2bcdeCg65jkm8oFqi1tuvAxyz"
+
+// ErrChecksum is returned when the checksum of a base58check string does not
+// match its contents.
+var ErrChecksum = errors.New("base58 checksum mismatch")
+
+var alphabetIndex = func() [256]int {
+	var index [256]int
+	for i := range index {
+		index[i] = -1
+	}
+	for i := 0; i < len(Alphabet); i++ {
+		index[Alphabet[i]] = i
+	}
+	return index
+}()
+
+// EncodeBase58 encodes b using the XRP Ledger alphabet. Leading zero bytes
+// become leading 'r' characters.
+func EncodeBase58(b []byte) string {
+	n := new(big.Int).SetBytes(b)
+	radix := big.NewInt(58)
+	mod := new(big.Int)
+	var out []byte
+	for n.Sign() > 0 {
+		n.DivMod(n, radix, mod)
+		out = append(out, Alphabet[mod.Int64()])
+	}
+	for _, c := range b {
+		if c != 0 {
+			break
+		}
+		out = append(out, Alphabet[0])
+	}
+	for i, j := 0, len(out)-1; i < j; i, j = i+1, j-1 {
+		out[i], out[j] = out[j], out[i]
+	}
+	return string(out)
+}
+
+// DecodeBase58 decodes a string in the XRP Ledger alphabet.
+func DecodeBase58(s string) ([]byte, error) {
+	n := new(big.Int)
+	radix := big.NewInt(58)
+	for i := 0; i < len(s); i++ {
+		digit := alphabetIndex[s[i]]
+		if digit < 0 {
+			return nil, fmt.Errorf("invalid base58 character %q", s[i])
+		}
+		n.Mul(n, radix)
+		n.Add(n, big.NewInt(int64(digit)))
+	}
+	zeros := 0
+	for zeros < len(s) && s[zeros] == Alphabet[0] {
+		zeros++
+	}
+	return append(make([]byte, zeros), n.Bytes()...), nil
+}
+
+// checksum returns the first four bytes of the double SHA-256 of b.
+func checksum(b []byte) []byte {
+	first := sha256.Sum256(b)
+	second := sha256.Sum256(first[:])
+	return second[:4]
+}
+
+// EncodeCheck prefixes payload with version, appends a checksum and encodes
+// the result in base58.
+func EncodeCheck(version []byte, payload []byte) string {
+	b := make([]byte, 0, len(version)+len(payload)+4)
+	b = append(b, version...)
+	b = append(b, payload...)
+	return EncodeBase58(append(b, checksum(b)...))
+}
+
+// DecodeCheck decodes a base58check string, verifies its checksum and
+// version prefix, and returns the payload. If length is positive, the
+// payload must be exactly that many bytes long.
+func DecodeCheck(s string, version []byte, length int) ([]byte, error) {
+	b, err := DecodeBase58(s)
+	if err != nil {
+		return nil, err
+	}
+	if len(b) < len(version)+4 {
+		return nil, errors.New("base58 string too short")
+	}
+	data, sum := b[:len(b)-4], b[len(b)-4:]
+	if !bytes.Equal(checksum(data), sum) {
+		return nil, ErrChecksum
+	}
+	if !bytes.Equal(data[:len(version)], version) {
+		return nil, fmt.Errorf("unexpected version prefix %X", data[:len(version)])
+	}
+	payload := data[len(version):]
+	if length > 0 && len(payload) != length {
+		return nil, fmt.Errorf("expected %d bytes of payload, got %d", length, len(payload))
+	}
+	return payload, nil
+}
@@ -0,0 +1,108 @@
+// Package addresscodec encodes and decodes the base58check strings used by
+// the XRP Ledger for classic addresses, public keys and seeds.
+// https://xrpl.org/docs/references/protocol/data-types/base58-encodings
+package addresscodec
+
+import (
+	"errors"
+)
+
+// Version prefixes of the base58check encodings.
+var (
+	AccountIDPrefix     = []byte{0x00}
+	AccountPublicPrefix = []byte{0x23}
+	NodePublicPrefix    = []byte{0x1C}
+	FamilySeedPrefix    = []byte{0x21}
+	ED25519SeedPrefix   = []byte{0x01, 0xE1, 0x4B}
+)
+
+const (
+	AccountIDLength = 20
+	PublicKeyLength = 33
+	SeedLength      = 16
+)
+
+// Algorithm is the signing algorithm a seed is used with.
+type Algorithm string
+
+const (
+	SECP256K1 Algorithm = "secp256k1"
+	ED25519   Algorithm = "ed25519"
+)
+
+// ErrInvalidSeed is returned for strings that are not secp256k1 or ed25519
+// seeds.
+var ErrInvalidSeed = errors.New("invalid seed")
+
+// EncodeAccountID encodes a 20 byte AccountID as a classic address.
+func EncodeAccountID(accountID []byte) (string, error) {
+	if len(accountID) != AccountIDLength {
+		return "", errors.New("AccountID must be 20 bytes")
+	}
+	return EncodeCheck(AccountIDPrefix, accountID), nil
+}
+
+// DecodeAccountID decodes a classic address to its 20 byte AccountID.
+func DecodeAccountID(address string) ([]byte, error) {
+	return DecodeCheck(address, AccountIDPrefix, AccountIDLength)
+}
+
+// IsValidClassicAddress reports whether address is a well formed classic
+// address with a valid checksum.
+func IsValidClassicAddress(address string) bool {
+	_, err := DecodeAccountID(address)
+	return err == nil
+}
+
+// EncodeNodePublic encodes a 33 byte validator or node public key.
+func EncodeNodePublic(publicKey []byte) (string, error) {
+	if len(publicKey) != PublicKeyLength {
+		return "", errors.New("public key must be 33 bytes")
+	}
+	return EncodeCheck(NodePublicPrefix, publicKey), nil
+}
+
+// DecodeNodePublic decodes an "n..." node public key.
+func DecodeNodePublic(key string) ([]byte, error) {
+	return DecodeCheck(key, NodePublicPrefix, PublicKeyLength)
+}
+
+// EncodeAccountPublic encodes a 33 byte account public key.
+func EncodeAccountPublic(publicKey []byte) (string, error) {
+	if len(publicKey) != PublicKeyLength {
+		return "", errors.New("public key must be 33 bytes")
+	}
+	return EncodeCheck(AccountPublicPrefix, publicKey), nil
+}
+
+// DecodeAccountPublic decodes an "a..." account public key.
+func DecodeAccountPublic(key string) ([]byte, error) {
+	return DecodeCheck(key, AccountPublicPrefix, PublicKeyLength)
+}
+
+// EncodeSeed encodes 16 bytes of entropy as a seed for the given algorithm.
+// secp256k1 seeds start with "s", ed25519 seeds with "sEd".
+func EncodeSeed(entropy []byte, algorithm Algorithm) (string, error) {
+	if len(entropy) != SeedLength {
+		return "", errors.New("seed entropy must be 16 bytes")
+	}
+	switch algorithm {
+	case SECP256K1:
+		return EncodeCheck(FamilySeedPrefix, entropy), nil
+	case ED25519:
+		return EncodeCheck(ED25519SeedPrefix, entropy), nil
+	default:
+		return "", errors.New("unknown algorithm: " + string(algorithm))
+	}
+}
+
+// DecodeSeed decodes a seed and returns its entropy and algorithm.
+func DecodeSeed(seed string) ([]byte, Algorithm, error) {
+	if entropy, err := DecodeCheck(seed, ED25519SeedPrefix, SeedLength); err == nil {
+		return entropy, ED25519, nil
+	}
+	if entropy, err := DecodeCheck(seed, FamilySeedPrefix, SeedLength); err == nil {
+		return entropy, SECP256K1, nil
+	}
+	return nil, "", ErrInvalidSeed
+}
@@ -0,0 +1,100 @@
+package addresscodec
+
+import (
+	"encoding/binary"
+	"errors"
+)
+
+// X-address prefixes for production and test networks (XLS-5). They make
+// X-addresses start with "X" and "T" respectively.
+// https://github.com/XRPLF/XRPL-Standards/tree/master/XLS-0005-tagged-addresses
+var (
+	XAddressMainPrefix = []byte{0x05, 0x44}
+	XAddressTestPrefix = []byte{0x04, 0x93}
+)
+
+// xAddressLength is the payload length after the prefix: AccountID, flag
+// byte and a 64 bit tag of which only the low 32 bits are used.
+const xAddressLength = AccountIDLength + 1 + 8
+
+// ErrInvalidXAddress is returned for strings that are not X-addresses.
+var ErrInvalidXAddress = errors.New("invalid X-address")
+
+// EncodeXAddress encodes an AccountID, optional destination tag and network
+// flag as an X-address.
+func EncodeXAddress(accountID []byte, tag uint32, hasTag bool, test bool) (string, error) {
+	if len(accountID) != AccountIDLength {
+		return "", errors.New("AccountID must be 20 bytes")
+	}
+	payload := make([]byte, xAddressLength)
+	copy(payload, accountID)
+	if hasTag {
+		payload[AccountIDLength] = 1
+		binary.LittleEndian.PutUint32(payload[AccountIDLength+1:], tag)
+	}
+	if test {
+		return EncodeCheck(XAddressTestPrefix, payload), nil
+	}
+	return EncodeCheck(XAddressMainPrefix, payload), nil
+}
+
+// DecodeXAddress decodes an X-address to its AccountID, destination tag and
+// network flag.
+func DecodeXAddress(xAddress string) (accountID []byte, tag uint32, hasTag bool, test bool, err error) {
+	payload, err := DecodeCheck(xAddress, XAddressMainPrefix, xAddressLength)
+	if err != nil {
+		payload, err = DecodeCheck(xAddress, XAddressTestPrefix, xAddressLength)
+		if err != nil {
+			return nil, 0, false, false, ErrInvalidXAddress
+		}
+		test = true
+	}
+
+	// Tags wider than 32 bits are reserved
+	rest := payload[AccountIDLength:]
+	switch rest[0] {
+	case 0:
+		for _, b := range rest[1:] {
+			if b != 0 {
+				return nil, 0, false, false, ErrInvalidXAddress
+			}
+		}
+	case 1:
+		for _, b := range rest[5:] {
+			if b != 0 {
+				return nil, 0, false, false, ErrInvalidXAddress
+			}
+		}
+		tag, hasTag = binary.LittleEndian.Uint32(rest[1:5]), true
+	default:
+		return nil, 0, false, false, ErrInvalidXAddress
+	}
+	return payload[:AccountIDLength], tag, hasTag, test, nil
+}
+
+// ClassicAddressToXAddress converts a classic address and optional tag to
+// an X-address.
+func ClassicAddressToXAddress(classicAddress string, tag uint32, hasTag bool, test bool) (string, error) {
+	accountID, err := DecodeAccountID(classicAddress)
+	if err != nil {
+		return "", err
+	}
+	return EncodeXAddress(accountID, tag, hasTag, test)
+}
+
+// XAddressToClassicAddress converts an X-address to a classic address, its
+// tag and network flag.
+func XAddressToClassicAddress(xAddress string) (classicAddress string, tag uint32, hasTag bool, test bool, err error) {
+	accountID, tag, hasTag, test, err := DecodeXAddress(xAddress)
+	if err != nil {
+		return "", 0, false, false, err
+	}
+	classicAddress, err = EncodeAccountID(accountID)
+	return classicAddress, tag, hasTag, test, err
+}
+
+// IsValidXAddress reports whether xAddress is a well formed X-address.
+func IsValidXAddress(xAddress string) bool {
+	_, _, _, _, err := DecodeXAddress(xAddress)
+	return err == nil
+}
@@ -0,0 +1,130 @@
+package xrpl
+
+import (
+	"errors"
+	"time"
+
+	"github.com/xrpscan/xrpl-go/methods"
+)
+
+// Admin methods. These require an admin connection to rippled, which usually
+// means connecting from localhost to a port with admin = [127.0.0.1].
+// https://xrpl.org/docs/references/http-websocket-apis/admin-api-methods
+
+// LedgerAccept closes the current open ledger. Only available when rippled is
+// running in standalone mode.
+func (c *Client) LedgerAccept() (methods.LedgerAcceptResponse, error) {
+	req := methods.LedgerAcceptRequest{}
+	req.Command = "ledger_accept"
+	var res methods.LedgerAcceptResponse
+	err := c.RequestInto(req, &res)
+	return res, err
+}
+
+// AdvanceLedger closes the current open ledger of a standalone server and
+// waits until the ledgerClosed message for it arrives. The client must be
+// subscribed to the ledger stream. Returns the index of the closed ledger.
+func (c *Client) AdvanceLedger(timeout time.Duration) (uint64, error) {
+	c.mutex.Lock()
+	subscribed := c.StreamSubscriptions[StreamTypeLedger]
+	c.mutex.Unlock()
+	if !subscribed {
+		return 0, errors.New("AdvanceLedger requires a subscription to the ledger stream")
+	}
+
+	res, err := c.LedgerAccept()
+	if err != nil {
+		return 0, err
+	}
+	if res.Result.LedgerCurrentIndex == 0 {
+		return 0, errors.New("ledger_accept returned no ledger_current_index")
+	}
+	closedIndex := res.Result.LedgerCurrentIndex - 1
+	return closedIndex, c.health.waitForLedger(closedIndex, timeout)
+}
+
+// ValidationCreate generates validator keys. If req.Secret is empty, a
+// random seed is used.
+func (c *Client) ValidationCreate(req methods.ValidationCreateRequest) (methods.ValidationCreateResponse, error) {
+	req.Command = "validation_create"
+	var res methods.ValidationCreateResponse
+	err := c.RequestInto(req, &res)
+	return res, err
+}
+
+// WalletPropose generates a key pair and XRP Ledger address.
+func (c *Client) WalletPropose(req methods.WalletProposeRequest) (methods.WalletProposeResponse, error) {
+	req.Command = "wallet_propose"
+	var res methods.WalletProposeResponse
+	err := c.RequestInto(req, &res)
+	return res, err
+}
+
+// Peers lists the peers connected to the server.
+func (c *Client) Peers() (methods.PeersResponse, error) {
+	req := methods.PeersRequest{}
+	req.Command = "peers"
+	var res methods.PeersResponse
+	err := c.RequestInto(req, &res)
+	return res, err
+}
+
+// LogLevel changes the server's log verbosity, or lists current log levels
+// when req.Severity is empty.
+func (c *Client) LogLevel(req methods.LogLevelRequest) (methods.LogLevelResponse, error) {
+	req.Command = "log_level"
+	var res methods.LogLevelResponse
+	err := c.RequestInto(req, &res)
+	return res, err
+}
+
+// CanDelete sets or queries the latest ledger that online deletion may
+// delete.
+func (c *Client) CanDelete(req methods.CanDeleteRequest) (methods.CanDeleteResponse, error) {
+	req.Command = "can_delete"
+	var res methods.CanDeleteResponse
+	err := c.RequestInto(req, &res)
+	return res, err
+}
+
+// Connect makes the server connect to a specific peer.
+func (c *Client) Connect(req methods.ConnectRequest) (methods.ConnectResponse, error) {
+	req.Command = "connect"
+	var res methods.ConnectResponse
+	err := c.RequestInto(req, &res)
+	return res, err
+}
+
+// Stop gracefully shuts down the server.
+func (c *Client) Stop() (methods.StopResponse, error) {
+	req := methods.StopRequest{}
+	req.Command = "stop"
+	var res methods.StopResponse
+	err := c.RequestInto(req, &res)
+	return res, err
+}
+
+// ConsensusInfo returns debugging information about the consensus process.
+func (c *Client) ConsensusInfo() (methods.ConsensusInfoResponse, error) {
+	req := methods.ConsensusInfoRequest{}
+	req.Command = "consensus_info"
+	var res methods.ConsensusInfoResponse
+	err := c.RequestInto(req, &res)
+	return res, err
+}
+
+// FetchInfo lists objects the server is currently fetching from the network.
+func (c *Client) FetchInfo(req methods.FetchInfoRequest) (methods.FetchInfoResponse, error) {
+	req.Command = "fetch_info"
+	var res methods.FetchInfoResponse
+	err := c.RequestInto(req, &res)
+	return res, err
+}
+
+// GetCounts returns in-memory object counts and other server health stats.
+func (c *Client) GetCounts(req methods.GetCountsRequest) (methods.GetCountsResponse, error) {
+	req.Command = "get_counts"
+	var res methods.GetCountsResponse
+	err := c.RequestInto(req, &res)
+	return res, err
+}
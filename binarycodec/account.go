@@ -0,0 +1,29 @@
+package binarycodec
+
+import (
+	"fmt"
+
+	"github.com/xrpscan/xrpl-go/addresscodec"
+)
+
+// encodeAccountID accepts a classic address or 40 characters of hex.
+func encodeAccountID(value interface{}) ([]byte, error) {
+	s, ok := value.(string)
+	if !ok {
+		return nil, fmt.Errorf("expected account address, got %v", value)
+	}
+	if len(s) == 40 {
+		if b, err := toHex(s, 20); err == nil {
+			return b, nil
+		}
+	}
+	b, err := addresscodec.DecodeAccountID(s)
+	if err != nil {
+		return nil, fmt.Errorf("invalid classic address %s: %w", s, err)
+	}
+	return b, nil
+}
+
+func decodeAccountID(b []byte) (interface{}, error) {
+	return addresscodec.EncodeAccountID(b)
+}
@@ -0,0 +1,287 @@
+package binarycodec
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math/big"
+	"strconv"
+	"strings"
+)
+
+const (
+	amountNotXRP   = 0x8000000000000000
+	amountPositive = 0x4000000000000000
+	amountMPT      = 0x20
+
+	minIOUExponent  = -96
+	maxIOUExponent  = 80
+	maxIOUPrecision = 16
+	minIOUMantissa  = 1000000000000000
+	maxIOUMantissa  = 9999999999999999
+
+	maxDrops = 100000000000000000 // 10^17
+)
+
+// encodeAmount accepts a string of XRP drops, an issued currency object
+// {"currency", "issuer", "value"} or an MPT object {"mpt_issuance_id", "value"}.
+func encodeAmount(s *binarySerializer, value interface{}) error {
+	switch v := value.(type) {
+	case string:
+		return encodeXRPAmount(s, v)
+	case json.Number:
+		return encodeXRPAmount(s, v.String())
+	case map[string]interface{}:
+		if id, ok := v["mpt_issuance_id"]; ok {
+			if _, ok := v["currency"]; ok {
+				return errors.New("MPT amount cannot have a currency")
+			}
+			if _, ok := v["issuer"]; ok {
+				return errors.New("MPT amount cannot have an issuer")
+			}
+			return encodeMPTAmount(s, id, v["value"])
+		}
+		return encodeIOUAmount(s, v)
+	default:
+		return fmt.Errorf("invalid amount: %v", value)
+	}
+}
+
+func encodeXRPAmount(s *binarySerializer, drops string) error {
+	n, err := strconv.ParseUint(drops, 10, 64)
+	if err != nil {
+		return fmt.Errorf("invalid XRP amount: %s", drops)
+	}
+	if n > maxDrops {
+		return fmt.Errorf("XRP amount out of range: %s", drops)
+	}
+	s.writeUInt64(n | amountPositive)
+	return nil
+}
+
+func encodeMPTAmount(s *binarySerializer, id, value interface{}) error {
+	issuance, err := toHex(id, 24)
+	if err != nil {
+		return err
+	}
+	str, ok := value.(string)
+	if !ok {
+		return fmt.Errorf("invalid MPT amount: %v", value)
+	}
+	// Values are decimal, or hex with a 0x prefix. Zero may carry a sign.
+	var n uint64
+	if strings.HasPrefix(str, "0x") {
+		n, err = strconv.ParseUint(str[2:], 16, 64)
+	} else {
+		n, err = strconv.ParseUint(strings.TrimPrefix(str, "-"), 10, 64)
+		if err == nil && n != 0 && strings.HasPrefix(str, "-") {
+			err = errors.New("negative")
+		}
+	}
+	if err != nil || n > 0x7FFFFFFFFFFFFFFF {
+		return fmt.Errorf("invalid MPT amount: %s", str)
+	}
+	s.WriteByte(0x60)
+	s.writeUInt64(n)
+	s.Write(issuance)
+	return nil
+}
+
+func encodeIOUAmount(s *binarySerializer, amount map[string]interface{}) error {
+	var raw string
+	switch v := amount["value"].(type) {
+	case string:
+		raw = v
+	case json.Number:
+		raw = v.String()
+	default:
+		return fmt.Errorf("invalid issued currency value: %v", amount["value"])
+	}
+	mantissa, exponent, negative, err := parseIOUValue(raw)
+	if err != nil {
+		return err
+	}
+	currency, err := encodeCurrency(amount["currency"])
+	if err != nil {
+		return err
+	}
+	if isXRPCurrency(currency) {
+		return errors.New("issued currency amount cannot use XRP as currency")
+	}
+	issuer, err := encodeAccountID(amount["issuer"])
+	if err != nil {
+		return err
+	}
+
+	n := uint64(amountNotXRP)
+	if mantissa != 0 {
+		if !negative {
+			n |= amountPositive
+		}
+		n |= uint64(exponent+97)<<54 | mantissa
+	}
+	s.writeUInt64(n)
+	s.Write(currency)
+	s.Write(issuer)
+	return nil
+}
+
+// parseIOUValue converts a decimal string to a normalized mantissa in
+// [10^15, 10^16) and exponent. Zero returns a zero mantissa.
+func parseIOUValue(value string) (mantissa uint64, exponent int, negative bool, err error) {
+	invalid := fmt.Errorf("invalid issued currency value: %s", value)
+	s := value
+	if strings.HasPrefix(s, "-") {
+		negative = true
+		s = s[1:]
+	} else if strings.HasPrefix(s, "+") {
+		s = s[1:]
+	}
+	if i := strings.IndexAny(s, "eE"); i >= 0 {
+		exponent, err = strconv.Atoi(s[i+1:])
+		if err != nil {
+			return 0, 0, false, invalid
+		}
+		s = s[:i]
+	}
+	digits := s
+	if i := strings.IndexByte(s, '.'); i >= 0 {
+		digits = s[:i] + s[i+1:]
+		exponent -= len(s) - i - 1
+	}
+	if len(digits) == 0 {
+		return 0, 0, false, invalid
+	}
+	for _, c := range digits {
+		if c < '0' || c > '9' {
+			return 0, 0, false, invalid
+		}
+	}
+
+	digits = strings.TrimLeft(digits, "0")
+	if len(digits) == 0 {
+		return 0, 0, false, nil
+	}
+	trimmed := strings.TrimRight(digits, "0")
+	exponent += len(digits) - len(trimmed)
+	digits = trimmed
+	if len(digits) > maxIOUPrecision {
+		return 0, 0, false, fmt.Errorf("issued currency value has too many significant digits: %s", value)
+	}
+	exponent -= maxIOUPrecision - len(digits)
+	digits += strings.Repeat("0", maxIOUPrecision-len(digits))
+	mantissa, _ = strconv.ParseUint(digits, 10, 64)
+	if exponent < minIOUExponent || exponent > maxIOUExponent {
+		return 0, 0, false, fmt.Errorf("issued currency value out of range: %s", value)
+	}
+	return mantissa, exponent, negative, nil
+}
+
+func decodeAmount(p *binaryParser) (interface{}, error) {
+	first, err := p.peek()
+	if err != nil {
+		return nil, err
+	}
+	switch {
+	case first&0x80 != 0:
+		return decodeIOUAmount(p)
+	case first&amountMPT != 0:
+		return decodeMPTAmount(p)
+	default:
+		n, err := p.readUInt64()
+		if err != nil {
+			return nil, err
+		}
+		drops := strconv.FormatUint(n&^(amountNotXRP|amountPositive), 10)
+		if n&amountPositive == 0 && drops != "0" {
+			drops = "-" + drops
+		}
+		return drops, nil
+	}
+}
+
+func decodeMPTAmount(p *binaryParser) (interface{}, error) {
+	b, err := p.read(33)
+	if err != nil {
+		return nil, err
+	}
+	n := new(big.Int).SetBytes(b[1:9]).String()
+	if b[0]&0x40 == 0 && n != "0" {
+		n = "-" + n
+	}
+	return map[string]interface{}{
+		"mpt_issuance_id": hexUpper(b[9:]),
+		"value":           n,
+	}, nil
+}
+
+func decodeIOUAmount(p *binaryParser) (interface{}, error) {
+	n, err := p.readUInt64()
+	if err != nil {
+		return nil, err
+	}
+	currency, err := p.read(20)
+	if err != nil {
+		return nil, err
+	}
+	issuer, err := p.read(20)
+	if err != nil {
+		return nil, err
+	}
+	code, err := decodeCurrency(currency)
+	if err != nil {
+		return nil, err
+	}
+	account, err := decodeAccountID(issuer)
+	if err != nil {
+		return nil, err
+	}
+
+	value := "0"
+	mantissa := n & (1<<54 - 1)
+	if mantissa != 0 {
+		exponent := int((n>>54)&0xFF) - 97
+		value = formatDecimal(mantissa, exponent, n&amountPositive == 0)
+	}
+	return map[string]interface{}{
+		"currency": code,
+		"issuer":   account,
+		"value":    value,
+	}, nil
+}
+
+// formatDecimal renders mantissa * 10^exponent the way rippled and
+// xrpl.js do: plain notation, switching to exponential notation when the
+// decimal exponent is below -6 or above 20.
+func formatDecimal(mantissa uint64, exponent int, negative bool) string {
+	for mantissa%10 == 0 {
+		mantissa /= 10
+		exponent++
+	}
+	digits := strconv.FormatUint(mantissa, 10)
+	e := exponent + len(digits) - 1
+
+	var out string
+	switch {
+	case e < -6 || e > 20:
+		out = digits[:1]
+		if len(digits) > 1 {
+			out += "." + digits[1:]
+		}
+		if e < 0 {
+			out += "e-" + strconv.Itoa(-e)
+		} else {
+			out += "e+" + strconv.Itoa(e)
+		}
+	case exponent >= 0:
+		out = digits + strings.Repeat("0", exponent)
+	case e >= 0:
+		out = digits[:e+1] + "." + digits[e+1:]
+	default:
+		out = "0." + strings.Repeat("0", -e-1) + digits
+	}
+	if negative {
+		out = "-" + out
+	}
+	return out
+}
@@ -0,0 +1,190 @@
+// Package binarycodec converts XRP Ledger transactions, ledger objects and
+// metadata between the canonical binary format and their JSON form.
+//
+// The JSON form matches what rippled returns with "binary": false and what
+// the models package unmarshals: amounts are strings of drops or objects,
+// hashes and blobs are uppercase hex, accounts are classic addresses and
+// enumerated fields such as TransactionType use their names.
+package binarycodec
+
+import (
+	"bytes"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strconv"
+)
+
+// Encode serializes a transaction, ledger object or metadata in JSON form
+// to uppercase hex using the default definitions.
+func Encode(obj map[string]interface{}) (string, error) {
+	return defaultDefinitions.Encode(obj)
+}
+
+// EncodeForSigning returns the data that is signed to single-sign a
+// transaction, using the default definitions.
+func EncodeForSigning(obj map[string]interface{}) (string, error) {
+	return defaultDefinitions.EncodeForSigning(obj)
+}
+
+// EncodeForMultisigning returns the data that signerAccount signs to add
+// its signature to a multi-signed transaction, using the default
+// definitions.
+func EncodeForMultisigning(obj map[string]interface{}, signerAccount string) (string, error) {
+	return defaultDefinitions.EncodeForMultisigning(obj, signerAccount)
+}
+
+// EncodeSigningFields serializes only the fields of obj covered by a
+// signature, without a hash prefix, using the default definitions.
+func EncodeSigningFields(obj map[string]interface{}) (string, error) {
+	return defaultDefinitions.EncodeSigningFields(obj)
+}
+
+// EncodeForSigningClaim returns the data that is signed to authorize a
+// payment channel claim: the CLM hash prefix, the 32 byte channel ID and
+// the amount of XRP, in drops, as a UInt64.
+func EncodeForSigningClaim(channel string, amount string) (string, error) {
+	channelID, err := hex.DecodeString(channel)
+	if err != nil || len(channelID) != 32 {
+		return "", fmt.Errorf("invalid channel ID: %q", channel)
+	}
+	drops, err := strconv.ParseUint(amount, 10, 64)
+	if err != nil {
+		return "", fmt.Errorf("invalid amount of drops: %q", amount)
+	}
+	var s binarySerializer
+	s.Write(HashPrefixPaymentChannelClaim)
+	s.Write(channelID)
+	s.writeUInt64(drops)
+	return hexUpper(s.Bytes()), nil
+}
+
+// Decode deserializes a hex encoded transaction, ledger object or metadata
+// blob into its JSON form using the default definitions.
+func Decode(hexEncoded string) (map[string]interface{}, error) {
+	return defaultDefinitions.Decode(hexEncoded)
+}
+
+// Marshal serializes any value whose JSON encoding is a transaction or
+// ledger object using the default definitions. Zero values are serialized
+// like any other, so optional struct fields should be tagged omitempty.
+func Marshal(v interface{}) (string, error) {
+	return defaultDefinitions.Marshal(v)
+}
+
+// Unmarshal decodes a hex encoded blob into v by way of its JSON form,
+// using the default definitions.
+func Unmarshal(hexEncoded string, v interface{}) error {
+	return defaultDefinitions.Unmarshal(hexEncoded, v)
+}
+
+// Encode serializes obj to uppercase hex. Keys that are not serialized
+// fields are ignored.
+func (d *Definitions) Encode(obj map[string]interface{}) (string, error) {
+	b, err := d.encode(obj, false)
+	if err != nil {
+		return "", err
+	}
+	return hexUpper(b), nil
+}
+
+// EncodeForSigning returns the data that is signed to single-sign a
+// transaction: the STX hash prefix followed by the fields of obj covered by
+// a signature.
+func (d *Definitions) EncodeForSigning(obj map[string]interface{}) (string, error) {
+	b, err := d.encode(obj, true)
+	if err != nil {
+		return "", err
+	}
+	return hexUpper(append(append([]byte{}, HashPrefixTransactionSign...), b...)), nil
+}
+
+// EncodeForMultisigning returns the data that signerAccount signs to add
+// its signature to a multi-signed transaction: the SMT hash prefix, the
+// fields of obj covered by a signature and the signer's AccountID.
+// SigningPubKey must be present and empty in multi-signed transactions.
+func (d *Definitions) EncodeForMultisigning(obj map[string]interface{}, signerAccount string) (string, error) {
+	if key, ok := obj["SigningPubKey"]; !ok || key != "" {
+		return "", errors.New("multi-signed transactions must have an empty SigningPubKey")
+	}
+	accountID, err := encodeAccountID(signerAccount)
+	if err != nil {
+		return "", err
+	}
+	b, err := d.encode(obj, true)
+	if err != nil {
+		return "", err
+	}
+	data := append(append([]byte{}, HashPrefixTransactionMultiSign...), b...)
+	return hexUpper(append(data, accountID...)), nil
+}
+
+// EncodeSigningFields serializes only the fields of obj covered by a
+// signature, without a hash prefix. Validations and manifests are signed
+// this way under their own prefixes.
+func (d *Definitions) EncodeSigningFields(obj map[string]interface{}) (string, error) {
+	b, err := d.encode(obj, true)
+	if err != nil {
+		return "", err
+	}
+	return hexUpper(b), nil
+}
+
+// Decode deserializes a hex encoded blob into its JSON form.
+func (d *Definitions) Decode(hexEncoded string) (map[string]interface{}, error) {
+	b, err := hex.DecodeString(hexEncoded)
+	if err != nil {
+		return nil, err
+	}
+	return decodeObject(&binaryParser{data: b}, false, d)
+}
+
+// Marshal serializes any value whose JSON encoding is a transaction or
+// ledger object.
+func (d *Definitions) Marshal(v interface{}) (string, error) {
+	obj, err := toJSONObject(v)
+	if err != nil {
+		return "", err
+	}
+	return d.Encode(obj)
+}
+
+// Unmarshal decodes a hex encoded blob into v by way of its JSON form.
+func (d *Definitions) Unmarshal(hexEncoded string, v interface{}) error {
+	obj, err := d.Decode(hexEncoded)
+	if err != nil {
+		return err
+	}
+	data, err := json.Marshal(obj)
+	if err != nil {
+		return err
+	}
+	return json.Unmarshal(data, v)
+}
+
+func (d *Definitions) encode(obj map[string]interface{}, signingOnly bool) ([]byte, error) {
+	var s binarySerializer
+	if err := encodeObject(&s, obj, signingOnly, d); err != nil {
+		return nil, err
+	}
+	return s.Bytes(), nil
+}
+
+// toJSONObject round trips v through encoding/json, keeping numbers exact.
+func toJSONObject(v interface{}) (map[string]interface{}, error) {
+	if obj, ok := v.(map[string]interface{}); ok {
+		return obj, nil
+	}
+	data, err := json.Marshal(v)
+	if err != nil {
+		return nil, err
+	}
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	decoder.UseNumber()
+	var obj map[string]interface{}
+	if err := decoder.Decode(&obj); err != nil {
+		return nil, err
+	}
+	return obj, nil
+}
@@ -0,0 +1,183 @@
+package binarycodec
+
+import (
+	"bytes"
+	"fmt"
+	"regexp"
+)
+
+var isoCodeRegex = regexp.MustCompile(`^[A-Za-z0-9?!@#$%^&*<>(){}\[\]|]{3}$`)
+
+// encodeCurrency accepts "XRP", a three character ISO-style code or 40
+// characters of hex.
+func encodeCurrency(value interface{}) ([]byte, error) {
+	s, ok := value.(string)
+	if !ok {
+		return nil, fmt.Errorf("expected currency code, got %v", value)
+	}
+	b := make([]byte, 20)
+	switch {
+	case s == "XRP":
+		return b, nil
+	case isoCodeRegex.MatchString(s):
+		copy(b[12:15], s)
+		return b, nil
+	case len(s) == 40:
+		return toHex(s, 20)
+	default:
+		return nil, fmt.Errorf("invalid currency code: %s", s)
+	}
+}
+
+func decodeCurrency(b []byte) (interface{}, error) {
+	if len(b) != 20 {
+		return nil, fmt.Errorf("invalid currency length: %d", len(b))
+	}
+	if isXRPCurrency(b) {
+		return "XRP", nil
+	}
+	if isStandardCurrency(b) {
+		code := string(b[12:15])
+		if code != "XRP" && isoCodeRegex.MatchString(code) {
+			return code, nil
+		}
+	}
+	return hexUpper(b), nil
+}
+
+func isXRPCurrency(b []byte) bool {
+	return bytes.Equal(b, make([]byte, 20))
+}
+
+// isStandardCurrency reports whether only bytes 12 to 14 of the currency
+// are set, as is the case for three character codes.
+func isStandardCurrency(b []byte) bool {
+	for i, c := range b {
+		if (i < 12 || i > 14) && c != 0 {
+			return false
+		}
+	}
+	return true
+}
+
+// encodeIssue encodes {"currency": "XRP"}, {"currency": ..., "issuer": ...}
+// or {"mpt_issuance_id": ...}.
+func encodeIssue(value interface{}) ([]byte, error) {
+	issue, ok := value.(map[string]interface{})
+	if !ok {
+		return nil, fmt.Errorf("expected issue object, got %v", value)
+	}
+	if id, ok := issue["mpt_issuance_id"]; ok {
+		b, err := toHex(id, 24)
+		if err != nil {
+			return nil, err
+		}
+		// The issuer is followed by a marker account ID of one, which is
+		// never a valid issuer, and the sequence. rippled copies the
+		// sequence out of the issuance ID in host order, so its bytes are
+		// reversed on the wire.
+		out := make([]byte, 0, 44)
+		out = append(out, b[4:]...)
+		out = append(out, make([]byte, 19)...)
+		out = append(out, 1)
+		return append(out, b[3], b[2], b[1], b[0]), nil
+	}
+	currency, err := encodeCurrency(issue["currency"])
+	if err != nil {
+		return nil, err
+	}
+	if isXRPCurrency(currency) {
+		return currency, nil
+	}
+	issuer, err := encodeAccountID(issue["issuer"])
+	if err != nil {
+		return nil, err
+	}
+	return append(currency, issuer...), nil
+}
+
+func decodeIssue(p *binaryParser) (interface{}, error) {
+	currency, err := p.read(20)
+	if err != nil {
+		return nil, err
+	}
+	code, err := decodeCurrency(currency)
+	if err != nil {
+		return nil, err
+	}
+	if code == "XRP" {
+		return map[string]interface{}{"currency": code}, nil
+	}
+	issuer, err := p.read(20)
+	if err != nil {
+		return nil, err
+	}
+	if bytes.Equal(issuer, append(make([]byte, 19), 1)) {
+		// The first 20 bytes were the issuer, followed by the sequence
+		sequence, err := p.read(4)
+		if err != nil {
+			return nil, err
+		}
+		id := append([]byte{sequence[3], sequence[2], sequence[1], sequence[0]}, currency...)
+		return map[string]interface{}{"mpt_issuance_id": hexUpper(id)}, nil
+	}
+	account, err := decodeAccountID(issuer)
+	if err != nil {
+		return nil, err
+	}
+	return map[string]interface{}{"currency": code, "issuer": account}, nil
+}
+
+// xChainBridgeFields are the members of an XChainBridge in serialized order.
+var xChainBridgeFields = []string{"LockingChainDoor", "LockingChainIssue", "IssuingChainDoor", "IssuingChainIssue"}
+
+func encodeXChainBridge(value interface{}) ([]byte, error) {
+	bridge, ok := value.(map[string]interface{})
+	if !ok {
+		return nil, fmt.Errorf("expected bridge object, got %v", value)
+	}
+	var out []byte
+	for i, name := range xChainBridgeFields {
+		if i%2 == 0 {
+			door, err := encodeAccountID(bridge[name])
+			if err != nil {
+				return nil, fmt.Errorf("%s: %w", name, err)
+			}
+			out = append(out, byte(len(door)))
+			out = append(out, door...)
+		} else {
+			issue, err := encodeIssue(bridge[name])
+			if err != nil {
+				return nil, fmt.Errorf("%s: %w", name, err)
+			}
+			out = append(out, issue...)
+		}
+	}
+	return out, nil
+}
+
+func decodeXChainBridge(p *binaryParser) (interface{}, error) {
+	bridge := make(map[string]interface{}, len(xChainBridgeFields))
+	for i, name := range xChainBridgeFields {
+		var value interface{}
+		var err error
+		if i%2 == 0 {
+			var n uint8
+			if n, err = p.readUInt8(); err != nil {
+				return nil, err
+			}
+			var door []byte
+			if door, err = p.read(int(n)); err != nil {
+				return nil, err
+			}
+			value, err = decodeAccountID(door)
+		} else {
+			value, err = decodeIssue(p)
+		}
+		if err != nil {
+			return nil, err
+		}
+		bridge[name] = value
+	}
+	return bridge, nil
+}
@@ -0,0 +1,232 @@
+package binarycodec
+
+import (
+	_ "embed"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+)
+
+// Field, type and enum tables of the XRP Ledger mainnet, in the
+// definitions.json format used by xrpl.js and returned by rippled's
+// server_definitions method.
+//
+//go:embed definitions.json
+var definitionsJSON []byte
+
+var defaultDefinitions = mustLoadDefinitions(definitionsJSON)
+
+// DefaultDefinitions returns the definitions built into the package, which
+// describe the XRP Ledger mainnet. They are used by the package level
+// Encode and Decode functions.
+func DefaultDefinitions() *Definitions {
+	return defaultDefinitions
+}
+
+// fieldInstance describes how a field is serialized.
+type fieldInstance struct {
+	Name           string
+	Nth            int32
+	Type           string
+	TypeCode       int32
+	IsVLEncoded    bool
+	IsSerialized   bool
+	IsSigningField bool
+}
+
+// ordinal orders fields within an STObject: by type code, then by nth.
+func (f *fieldInstance) ordinal() int32 {
+	return f.TypeCode<<16 | f.Nth
+}
+
+// header returns the field ID that precedes the field's value.
+func (f *fieldInstance) header() []byte {
+	switch {
+	case f.TypeCode < 16 && f.Nth < 16:
+		return []byte{byte(f.TypeCode<<4 | f.Nth)}
+	case f.TypeCode < 16:
+		return []byte{byte(f.TypeCode << 4), byte(f.Nth)}
+	case f.Nth < 16:
+		return []byte{byte(f.Nth), byte(f.TypeCode)}
+	default:
+		return []byte{0, byte(f.TypeCode), byte(f.Nth)}
+	}
+}
+
+type fieldKey struct {
+	typeCode int32
+	nth      int32
+}
+
+// Definitions holds the field, type, ledger entry type, transaction type and
+// transaction result tables that drive the codec. Networks with other
+// amendments or features, such as Xahau or sidechains, use their own
+// Definitions. A Definitions value is immutable and safe for concurrent use.
+type Definitions struct {
+	hash               string
+	types              map[string]int32
+	ledgerEntryTypes   map[string]int32
+	transactionResults map[string]int32
+	transactionTypes   map[string]int32
+	permissions        map[string]int32
+	fields             map[string]*fieldInstance
+
+	fieldsByKey            map[fieldKey]*fieldInstance
+	ledgerEntryTypeNames   map[int32]string
+	transactionResultNames map[int32]string
+	transactionTypeNames   map[int32]string
+	permissionNames        map[int32]string
+}
+
+type definitionsFile struct {
+	Types              map[string]int32     `json:"TYPES"`
+	LedgerEntryTypes   map[string]int32     `json:"LEDGER_ENTRY_TYPES"`
+	Fields             [][2]json.RawMessage `json:"FIELDS"`
+	TransactionResults map[string]int32     `json:"TRANSACTION_RESULTS"`
+	TransactionTypes   map[string]int32     `json:"TRANSACTION_TYPES"`
+	Hash               string               `json:"hash"`
+}
+
+type fieldInfo struct {
+	Nth            int32  `json:"nth"`
+	IsVLEncoded    bool   `json:"isVLEncoded"`
+	IsSerialized   bool   `json:"isSerialized"`
+	IsSigningField bool   `json:"isSigningField"`
+	Type           string `json:"type"`
+}
+
+// LoadDefinitions parses definitions in definitions.json format. The result
+// object of a server_definitions response is accepted as is.
+func LoadDefinitions(data []byte) (*Definitions, error) {
+	var file definitionsFile
+	if err := json.Unmarshal(data, &file); err != nil {
+		return nil, err
+	}
+	if len(file.Types) == 0 || len(file.Fields) == 0 {
+		return nil, errors.New("definitions have no types or fields")
+	}
+
+	d := &Definitions{
+		hash:                   file.Hash,
+		types:                  file.Types,
+		ledgerEntryTypes:       file.LedgerEntryTypes,
+		transactionResults:     file.TransactionResults,
+		transactionTypes:       file.TransactionTypes,
+		fields:                 make(map[string]*fieldInstance, len(file.Fields)),
+		fieldsByKey:            make(map[fieldKey]*fieldInstance, len(file.Fields)),
+		ledgerEntryTypeNames:   reverse(file.LedgerEntryTypes),
+		transactionResultNames: reverse(file.TransactionResults),
+		transactionTypeNames:   reverse(file.TransactionTypes),
+	}
+	d.permissions = permissions(file.TransactionTypes)
+	d.permissionNames = reverse(d.permissions)
+	for _, entry := range file.Fields {
+		var name string
+		var info fieldInfo
+		if err := json.Unmarshal(entry[0], &name); err != nil {
+			return nil, err
+		}
+		if err := json.Unmarshal(entry[1], &info); err != nil {
+			return nil, err
+		}
+		typeCode, ok := d.types[info.Type]
+		if !ok {
+			return nil, fmt.Errorf("field %s has unknown type %s", name, info.Type)
+		}
+		field := &fieldInstance{
+			Name:           name,
+			Nth:            info.Nth,
+			Type:           info.Type,
+			TypeCode:       typeCode,
+			IsVLEncoded:    info.IsVLEncoded,
+			IsSerialized:   info.IsSerialized,
+			IsSigningField: info.IsSigningField,
+		}
+		d.fields[name] = field
+		if field.IsSerialized {
+			d.fieldsByKey[fieldKey{typeCode, info.Nth}] = field
+		}
+	}
+	return d, nil
+}
+
+// LoadDefinitionsFile reads definitions from a definitions.json file.
+func LoadDefinitionsFile(path string) (*Definitions, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	return LoadDefinitions(data)
+}
+
+// Hash returns the hash rippled reported for these definitions, or the
+// empty string if they were not loaded from a server_definitions response.
+func (d *Definitions) Hash() string {
+	return d.hash
+}
+
+// HasField reports whether name is a serialized field.
+func (d *Definitions) HasField(name string) bool {
+	field, ok := d.fields[name]
+	return ok && field.IsSerialized
+}
+
+// TransactionType returns the name of a transaction type code.
+func (d *Definitions) TransactionType(code int32) (string, bool) {
+	name, ok := d.transactionTypeNames[code]
+	return name, ok
+}
+
+// TransactionTypeCode returns the code of a named transaction type.
+func (d *Definitions) TransactionTypeCode(name string) (int32, bool) {
+	code, ok := d.transactionTypes[name]
+	return code, ok
+}
+
+// granularPermissions are the PermissionValue codes of the delegatable
+// permissions that are narrower than a whole transaction type. They are
+// fixed by rippled and not part of definitions.json.
+var granularPermissions = map[string]int32{
+	"TrustlineAuthorize":     65537,
+	"TrustlineFreeze":        65538,
+	"TrustlineUnfreeze":      65539,
+	"AccountDomainSet":       65540,
+	"AccountEmailHashSet":    65541,
+	"AccountMessageKeySet":   65542,
+	"AccountTransferRateSet": 65543,
+	"AccountTickSizeSet":     65544,
+	"PaymentMint":            65545,
+	"PaymentBurn":            65546,
+	"MPTokenIssuanceLock":    65547,
+	"MPTokenIssuanceUnlock":  65548,
+}
+
+// permissions returns the PermissionValue codes by name: the granular
+// permissions, and every transaction type as its code plus one.
+func permissions(transactionTypes map[string]int32) map[string]int32 {
+	m := make(map[string]int32, len(granularPermissions)+len(transactionTypes))
+	for name, code := range granularPermissions {
+		m[name] = code
+	}
+	for name, code := range transactionTypes {
+		m[name] = code + 1
+	}
+	return m
+}
+
+func mustLoadDefinitions(data []byte) *Definitions {
+	d, err := LoadDefinitions(data)
+	if err != nil {
+		panic("binarycodec: invalid definitions: " + err.Error())
+	}
+	return d
+}
+
+func reverse(m map[string]int32) map[int32]string {
+	r := make(map[int32]string, len(m))
+	for k, v := range m {
+		r[v] = k
+	}
+	return r
+}
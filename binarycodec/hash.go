@@ -0,0 +1,49 @@
+package binarycodec
+
+import (
+	"crypto/sha512"
+	"encoding/hex"
+	"errors"
+)
+
+// Sha512Half returns the first 32 bytes of the SHA-512 hash of the
+// concatenation of data, the hash function used throughout the XRP Ledger.
+func Sha512Half(data ...[]byte) []byte {
+	h := sha512.New()
+	for _, d := range data {
+		h.Write(d)
+	}
+	return h.Sum(nil)[:32]
+}
+
+// HashTransaction returns the hash of a signed tx_blob, which is the
+// transaction's ID: the SHA-512Half of the blob with the TXN hash prefix.
+func HashTransaction(txBlob string) (string, error) {
+	blob, err := hex.DecodeString(txBlob)
+	if err != nil {
+		return "", err
+	}
+	return hexUpper(Sha512Half(HashPrefixTransactionID, blob)), nil
+}
+
+// HashTransactionJSON returns the hash of a signed transaction in JSON form,
+// using the default definitions.
+func HashTransactionJSON(tx map[string]interface{}) (string, error) {
+	return defaultDefinitions.HashTransactionJSON(tx)
+}
+
+// HashTransactionJSON returns the hash of a signed transaction in JSON form.
+// The transaction must carry a TxnSignature or Signers, since unsigned
+// transactions have no ID.
+func (d *Definitions) HashTransactionJSON(tx map[string]interface{}) (string, error) {
+	_, signed := tx["TxnSignature"]
+	_, multiSigned := tx["Signers"]
+	if !signed && !multiSigned {
+		return "", errors.New("transaction is not signed")
+	}
+	txBlob, err := d.Encode(tx)
+	if err != nil {
+		return "", err
+	}
+	return HashTransaction(txBlob)
+}
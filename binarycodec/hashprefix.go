@@ -0,0 +1,47 @@
+package binarycodec
+
+// Hash prefixes are prepended to serialized data before it is hashed or
+// signed, so that data of one kind can never be mistaken for another. Each
+// is three ASCII characters followed by a zero byte.
+// https://github.com/XRPLF/rippled/blob/develop/include/xrpl/protocol/HashPrefix.h
+var (
+	// HashPrefixTransactionID precedes a signed transaction when
+	// computing its hash (TXN).
+	HashPrefixTransactionID = []byte{'T', 'X', 'N', 0}
+
+	// HashPrefixTransactionSign precedes a transaction's signing fields
+	// when it is single-signed (STX).
+	HashPrefixTransactionSign = []byte{'S', 'T', 'X', 0}
+
+	// HashPrefixTransactionMultiSign precedes a transaction's signing
+	// fields when it is signed by one signer of a multi-signature (SMT).
+	HashPrefixTransactionMultiSign = []byte{'S', 'M', 'T', 0}
+
+	// HashPrefixLedgerMaster precedes a serialized ledger header when
+	// computing the ledger hash (LWR).
+	HashPrefixLedgerMaster = []byte{'L', 'W', 'R', 0}
+
+	// HashPrefixInnerNode precedes the child hashes of a SHAMap inner
+	// node (MIN).
+	HashPrefixInnerNode = []byte{'M', 'I', 'N', 0}
+
+	// HashPrefixLeafNode precedes the data of a ledger entry in the state
+	// tree (MLN).
+	HashPrefixLeafNode = []byte{'M', 'L', 'N', 0}
+
+	// HashPrefixTransactionNode precedes a transaction and its metadata in
+	// the transaction tree (SND).
+	HashPrefixTransactionNode = []byte{'S', 'N', 'D', 0}
+
+	// HashPrefixValidation precedes the signing fields of a validation
+	// (VAL).
+	HashPrefixValidation = []byte{'V', 'A', 'L', 0}
+
+	// HashPrefixManifest precedes the signing fields of a validator
+	// manifest (MAN).
+	HashPrefixManifest = []byte{'M', 'A', 'N', 0}
+
+	// HashPrefixPaymentChannelClaim precedes the channel ID and amount of
+	// a payment channel claim (CLM).
+	HashPrefixPaymentChannelClaim = []byte{'C', 'L', 'M', 0}
+)
@@ -0,0 +1,110 @@
+package binarycodec
+
+import (
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// Number is encoded as a signed 64 bit mantissa followed by a signed 32 bit
+// exponent. Zero is encoded with the smallest exponent.
+const (
+	minNumberMantissa = 1000000000000000
+	maxNumberMantissa = 9999999999999999
+	numberZeroExp     = -2147483648
+)
+
+func encodeNumber(s *binarySerializer, value interface{}) error {
+	var raw string
+	switch v := value.(type) {
+	case string:
+		raw = v
+	case json.Number:
+		raw = v.String()
+	case float64:
+		raw = strconv.FormatFloat(v, 'g', -1, 64)
+	default:
+		return fmt.Errorf("invalid number: %v", value)
+	}
+	mantissa, exponent, err := parseNumber(raw)
+	if err != nil {
+		return err
+	}
+	s.writeUInt64(uint64(mantissa))
+	s.writeUInt32(uint32(int32(exponent)))
+	return nil
+}
+
+// parseNumber converts a decimal string to the canonical mantissa and
+// exponent of an STNumber: |mantissa| in [10^15, 10^16), truncating digits
+// that do not fit.
+func parseNumber(value string) (int64, int, error) {
+	invalid := fmt.Errorf("invalid number: %s", value)
+	s := value
+	negative := strings.HasPrefix(s, "-")
+	s = strings.TrimLeft(s, "+-")
+	exponent := 0
+	if i := strings.IndexAny(s, "eE"); i >= 0 {
+		e, err := strconv.Atoi(s[i+1:])
+		if err != nil {
+			return 0, 0, invalid
+		}
+		exponent = e
+		s = s[:i]
+	}
+	digits := s
+	if i := strings.IndexByte(s, '.'); i >= 0 {
+		digits = s[:i] + s[i+1:]
+		exponent -= len(s) - i - 1
+	}
+	if len(digits) == 0 {
+		return 0, 0, invalid
+	}
+	for _, c := range digits {
+		if c < '0' || c > '9' {
+			return 0, 0, invalid
+		}
+	}
+	digits = strings.TrimLeft(digits, "0")
+	if len(digits) == 0 {
+		return 0, numberZeroExp, nil
+	}
+	if len(digits) > 16 {
+		exponent += len(digits) - 16
+		digits = digits[:16]
+	}
+	mantissa, _ := strconv.ParseUint(digits, 10, 64)
+	for mantissa < minNumberMantissa {
+		mantissa *= 10
+		exponent--
+	}
+	for mantissa > maxNumberMantissa {
+		mantissa /= 10
+		exponent++
+	}
+	if negative {
+		return -int64(mantissa), exponent, nil
+	}
+	return int64(mantissa), exponent, nil
+}
+
+func decodeNumber(p *binaryParser) (interface{}, error) {
+	m, err := p.readUInt64()
+	if err != nil {
+		return nil, err
+	}
+	e, err := p.readUInt32()
+	if err != nil {
+		return nil, err
+	}
+	mantissa, exponent := int64(m), int(int32(e))
+	if mantissa == 0 {
+		return "0", nil
+	}
+	negative := mantissa < 0
+	if negative {
+		mantissa = -mantissa
+	}
+	return formatDecimal(uint64(mantissa), exponent, negative), nil
+}
@@ -0,0 +1,142 @@
+package binarycodec
+
+import (
+	"encoding/binary"
+	"errors"
+	"fmt"
+)
+
+var errUnexpectedEnd = errors.New("unexpected end of binary data")
+
+// binaryParser reads serialized fields from a byte slice.
+type binaryParser struct {
+	data []byte
+	pos  int
+}
+
+func (p *binaryParser) end() bool {
+	return p.pos >= len(p.data)
+}
+
+func (p *binaryParser) peek() (byte, error) {
+	if p.end() {
+		return 0, errUnexpectedEnd
+	}
+	return p.data[p.pos], nil
+}
+
+func (p *binaryParser) read(n int) ([]byte, error) {
+	if n < 0 || p.pos+n > len(p.data) {
+		return nil, errUnexpectedEnd
+	}
+	b := p.data[p.pos : p.pos+n]
+	p.pos += n
+	return b, nil
+}
+
+func (p *binaryParser) readUInt8() (uint8, error) {
+	b, err := p.read(1)
+	if err != nil {
+		return 0, err
+	}
+	return b[0], nil
+}
+
+func (p *binaryParser) readUInt16() (uint16, error) {
+	b, err := p.read(2)
+	if err != nil {
+		return 0, err
+	}
+	return binary.BigEndian.Uint16(b), nil
+}
+
+func (p *binaryParser) readUInt32() (uint32, error) {
+	b, err := p.read(4)
+	if err != nil {
+		return 0, err
+	}
+	return binary.BigEndian.Uint32(b), nil
+}
+
+func (p *binaryParser) readUInt64() (uint64, error) {
+	b, err := p.read(8)
+	if err != nil {
+		return 0, err
+	}
+	return binary.BigEndian.Uint64(b), nil
+}
+
+// readLengthPrefix reads the length of a variable length field.
+func (p *binaryParser) readLengthPrefix() (int, error) {
+	b1, err := p.readUInt8()
+	if err != nil {
+		return 0, err
+	}
+	switch {
+	case b1 <= 192:
+		return int(b1), nil
+	case b1 <= 240:
+		b2, err := p.readUInt8()
+		if err != nil {
+			return 0, err
+		}
+		return 193 + (int(b1)-193)*256 + int(b2), nil
+	case b1 <= 254:
+		b, err := p.read(2)
+		if err != nil {
+			return 0, err
+		}
+		return 12481 + (int(b1)-241)*65536 + int(b[0])*256 + int(b[1]), nil
+	default:
+		return 0, errors.New("invalid variable length indicator")
+	}
+}
+
+// readField reads a field header and returns the field it identifies.
+func (p *binaryParser) readField(d *Definitions) (*fieldInstance, error) {
+	b, err := p.readUInt8()
+	if err != nil {
+		return nil, err
+	}
+	typeCode, nth := int32(b>>4), int32(b&0x0F)
+	if typeCode == 0 {
+		b, err := p.readUInt8()
+		if err != nil {
+			return nil, err
+		}
+		if b < 16 {
+			return nil, errors.New("invalid field type code")
+		}
+		typeCode = int32(b)
+	}
+	if nth == 0 {
+		b, err := p.readUInt8()
+		if err != nil {
+			return nil, err
+		}
+		if b < 16 {
+			return nil, errors.New("invalid field code")
+		}
+		nth = int32(b)
+	}
+	field, ok := d.fieldsByKey[fieldKey{typeCode, nth}]
+	if !ok {
+		return nil, fmt.Errorf("unknown field with type code %d and field code %d", typeCode, nth)
+	}
+	return field, nil
+}
+
+// DecodeVL reads a length prefixed value from the start of data and returns
+// it along with the bytes that follow it.
+func DecodeVL(data []byte) (value []byte, rest []byte, err error) {
+	p := &binaryParser{data: data}
+	n, err := p.readLengthPrefix()
+	if err != nil {
+		return nil, nil, err
+	}
+	value, err = p.read(n)
+	if err != nil {
+		return nil, nil, err
+	}
+	return value, data[p.pos:], nil
+}
@@ -0,0 +1,124 @@
+package binarycodec
+
+import (
+	"fmt"
+)
+
+const (
+	pathSeparator = 0xFF
+	pathSetEnd    = 0x00
+
+	pathStepAccount  = 0x01
+	pathStepCurrency = 0x10
+	pathStepIssuer   = 0x20
+)
+
+// encodePathSet encodes an array of paths, each an array of steps with
+// optional account, currency and issuer members.
+func encodePathSet(s *binarySerializer, value interface{}) error {
+	paths, ok := value.([]interface{})
+	if !ok {
+		return fmt.Errorf("expected array of paths, got %v", value)
+	}
+	for i, p := range paths {
+		if i > 0 {
+			s.WriteByte(pathSeparator)
+		}
+		steps, ok := p.([]interface{})
+		if !ok {
+			return fmt.Errorf("expected array of path steps, got %v", p)
+		}
+		for _, st := range steps {
+			step, ok := st.(map[string]interface{})
+			if !ok {
+				return fmt.Errorf("expected path step object, got %v", st)
+			}
+			if err := encodePathStep(s, step); err != nil {
+				return err
+			}
+		}
+	}
+	s.WriteByte(pathSetEnd)
+	return nil
+}
+
+func encodePathStep(s *binarySerializer, step map[string]interface{}) error {
+	var kind byte
+	var body []byte
+	if account, ok := step["account"]; ok {
+		b, err := encodeAccountID(account)
+		if err != nil {
+			return err
+		}
+		kind |= pathStepAccount
+		body = append(body, b...)
+	}
+	if currency, ok := step["currency"]; ok {
+		b, err := encodeCurrency(currency)
+		if err != nil {
+			return err
+		}
+		kind |= pathStepCurrency
+		body = append(body, b...)
+	}
+	if issuer, ok := step["issuer"]; ok {
+		b, err := encodeAccountID(issuer)
+		if err != nil {
+			return err
+		}
+		kind |= pathStepIssuer
+		body = append(body, b...)
+	}
+	s.WriteByte(kind)
+	s.Write(body)
+	return nil
+}
+
+func decodePathSet(p *binaryParser) (interface{}, error) {
+	paths := []interface{}{}
+	path := []interface{}{}
+	for {
+		kind, err := p.readUInt8()
+		if err != nil {
+			return nil, err
+		}
+		switch kind {
+		case pathSetEnd:
+			return append(paths, path), nil
+		case pathSeparator:
+			paths = append(paths, path)
+			path = []interface{}{}
+			continue
+		}
+
+		step := make(map[string]interface{}, 3)
+		if kind&pathStepAccount != 0 {
+			if step["account"], err = readAccountID(p); err != nil {
+				return nil, err
+			}
+		}
+		if kind&pathStepCurrency != 0 {
+			b, err := p.read(20)
+			if err != nil {
+				return nil, err
+			}
+			if step["currency"], err = decodeCurrency(b); err != nil {
+				return nil, err
+			}
+		}
+		if kind&pathStepIssuer != 0 {
+			if step["issuer"], err = readAccountID(p); err != nil {
+				return nil, err
+			}
+		}
+		path = append(path, step)
+	}
+}
+
+func readAccountID(p *binaryParser) (interface{}, error) {
+	b, err := p.read(20)
+	if err != nil {
+		return nil, err
+	}
+	return decodeAccountID(b)
+}
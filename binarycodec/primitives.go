@@ -0,0 +1,315 @@
+package binarycodec
+
+import (
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+)
+
+// Hash and fixed width unsigned integer types are encoded as raw bytes of
+// the given width and shown as uppercase hex in JSON.
+var fixedWidths = map[string]int{
+	"Hash128": 16,
+	"Hash160": 20,
+	"Hash192": 24,
+	"Hash256": 32,
+	"UInt96":  12,
+	"UInt384": 48,
+	"UInt512": 64,
+}
+
+// base10UInt64Fields are UInt64 fields shown as decimal rather than hex.
+var base10UInt64Fields = map[string]bool{
+	"MaximumAmount":     true,
+	"OutstandingAmount": true,
+	"MPTAmount":         true,
+}
+
+// toUint64 converts a JSON number, or a string holding one, to a uint64.
+func toUint64(value interface{}, bits int) (uint64, error) {
+	var n uint64
+	var err error
+	switch v := value.(type) {
+	case float64:
+		if v < 0 || v != math.Trunc(v) || v > math.MaxUint64 {
+			return 0, fmt.Errorf("invalid unsigned integer: %v", v)
+		}
+		n = uint64(v)
+	case json.Number:
+		n, err = strconv.ParseUint(v.String(), 10, 64)
+	case string:
+		n, err = strconv.ParseUint(v, 10, 64)
+	case int:
+		if v < 0 {
+			return 0, fmt.Errorf("invalid unsigned integer: %d", v)
+		}
+		n = uint64(v)
+	case int32:
+		if v < 0 {
+			return 0, fmt.Errorf("invalid unsigned integer: %d", v)
+		}
+		n = uint64(v)
+	case int64:
+		if v < 0 {
+			return 0, fmt.Errorf("invalid unsigned integer: %d", v)
+		}
+		n = uint64(v)
+	case uint:
+		n = uint64(v)
+	case uint8:
+		n = uint64(v)
+	case uint16:
+		n = uint64(v)
+	case uint32:
+		n = uint64(v)
+	case uint64:
+		n = v
+	default:
+		return 0, fmt.Errorf("invalid unsigned integer: %v", value)
+	}
+	if err != nil {
+		return 0, err
+	}
+	if bits < 64 && n >= 1<<bits {
+		return 0, fmt.Errorf("unsigned integer out of range for %d bits: %d", bits, n)
+	}
+	return n, nil
+}
+
+// toInt64 converts a JSON number, or a string holding one, to an int64.
+func toInt64(value interface{}, bits int) (int64, error) {
+	var n int64
+	var err error
+	switch v := value.(type) {
+	case float64:
+		if v != math.Trunc(v) || v < math.MinInt64 || v > math.MaxInt64 {
+			return 0, fmt.Errorf("invalid integer: %v", v)
+		}
+		n = int64(v)
+	case json.Number:
+		n, err = strconv.ParseInt(v.String(), 10, 64)
+	case string:
+		n, err = strconv.ParseInt(v, 10, 64)
+	case int:
+		n = int64(v)
+	case int32:
+		n = int64(v)
+	case int64:
+		n = v
+	case uint32:
+		n = int64(v)
+	default:
+		return 0, fmt.Errorf("invalid integer: %v", value)
+	}
+	if err != nil {
+		return 0, err
+	}
+	if bits < 64 && (n < -(1<<(bits-1)) || n >= 1<<(bits-1)) {
+		return 0, fmt.Errorf("integer out of range for %d bits: %d", bits, n)
+	}
+	return n, nil
+}
+
+// toHex decodes a hex string value of an optional exact length in bytes.
+func toHex(value interface{}, length int) ([]byte, error) {
+	s, ok := value.(string)
+	if !ok {
+		return nil, fmt.Errorf("expected hex string, got %v", value)
+	}
+	b, err := hex.DecodeString(s)
+	if err != nil {
+		return nil, err
+	}
+	if length >= 0 && len(b) != length {
+		return nil, fmt.Errorf("expected %d bytes of hex, got %d", length, len(b))
+	}
+	return b, nil
+}
+
+func hexUpper(b []byte) string {
+	return strings.ToUpper(hex.EncodeToString(b))
+}
+
+// enumCode maps the name of a transaction type, ledger entry type,
+// transaction result or delegatable permission to its code. Numeric values are passed through.
+func enumCode(field string, value interface{}, d *Definitions) (interface{}, error) {
+	name, ok := value.(string)
+	if !ok {
+		return value, nil
+	}
+	var table map[string]int32
+	switch field {
+	case "TransactionType":
+		table = d.transactionTypes
+	case "LedgerEntryType":
+		table = d.ledgerEntryTypes
+	case "TransactionResult":
+		table = d.transactionResults
+	case "PermissionValue":
+		table = d.permissions
+	default:
+		return value, nil
+	}
+	code, ok := table[name]
+	if !ok {
+		return nil, fmt.Errorf("unknown %s: %s", field, name)
+	}
+	return int64(code), nil
+}
+
+// enumName is the inverse of enumCode. Unknown codes are returned as numbers.
+func enumName(field string, code int32, d *Definitions) interface{} {
+	var table map[int32]string
+	switch field {
+	case "TransactionType":
+		table = d.transactionTypeNames
+	case "LedgerEntryType":
+		table = d.ledgerEntryTypeNames
+	case "TransactionResult":
+		table = d.transactionResultNames
+	case "PermissionValue":
+		table = d.permissionNames
+	default:
+		return code
+	}
+	if name, ok := table[code]; ok {
+		return name
+	}
+	return code
+}
+
+func encodeUInt(s *binarySerializer, field *fieldInstance, value interface{}, bits int, d *Definitions) error {
+	value, err := enumCode(field.Name, value, d)
+	if err != nil {
+		return err
+	}
+	if bits == 64 {
+		return encodeUInt64(s, field, value)
+	}
+	n, err := toUint64(value, bits)
+	if err != nil {
+		return fmt.Errorf("%s: %w", field.Name, err)
+	}
+	switch bits {
+	case 8:
+		s.WriteByte(byte(n))
+	case 16:
+		s.writeUInt16(uint16(n))
+	case 32:
+		s.writeUInt32(uint32(n))
+	}
+	return nil
+}
+
+// encodeUInt64 accepts hex strings, or decimal strings for the fields in
+// base10UInt64Fields. JSON numbers are accepted for either.
+func encodeUInt64(s *binarySerializer, field *fieldInstance, value interface{}) error {
+	var n uint64
+	var err error
+	if str, ok := value.(string); ok {
+		if base10UInt64Fields[field.Name] {
+			n, err = strconv.ParseUint(str, 10, 64)
+		} else {
+			n, err = strconv.ParseUint(str, 16, 64)
+		}
+	} else {
+		n, err = toUint64(value, 64)
+	}
+	if err != nil {
+		return fmt.Errorf("%s: %w", field.Name, err)
+	}
+	s.writeUInt64(n)
+	return nil
+}
+
+func decodeUInt(p *binaryParser, field *fieldInstance, bits int, d *Definitions) (interface{}, error) {
+	switch bits {
+	case 8:
+		n, err := p.readUInt8()
+		if err != nil {
+			return nil, err
+		}
+		if field.Name == "TransactionResult" {
+			return enumName(field.Name, int32(n), d), nil
+		}
+		return uint32(n), nil
+	case 16:
+		n, err := p.readUInt16()
+		if err != nil {
+			return nil, err
+		}
+		return enumName(field.Name, int32(n), d), nil
+	case 32:
+		n, err := p.readUInt32()
+		if err != nil {
+			return nil, err
+		}
+		if field.Name == "PermissionValue" {
+			if name, ok := d.permissionNames[int32(n)]; ok {
+				return name, nil
+			}
+		}
+		return n, nil
+	default:
+		n, err := p.readUInt64()
+		if err != nil {
+			return nil, err
+		}
+		if base10UInt64Fields[field.Name] {
+			return strconv.FormatUint(n, 10), nil
+		}
+		return strings.ToUpper(fmt.Sprintf("%016x", n)), nil
+	}
+}
+
+func encodeInt(s *binarySerializer, field *fieldInstance, value interface{}, bits int) error {
+	n, err := toInt64(value, bits)
+	if err != nil {
+		return fmt.Errorf("%s: %w", field.Name, err)
+	}
+	if bits == 32 {
+		s.writeUInt32(uint32(int32(n)))
+	} else {
+		s.writeUInt64(uint64(n))
+	}
+	return nil
+}
+
+func decodeInt(p *binaryParser, bits int) (interface{}, error) {
+	if bits == 32 {
+		n, err := p.readUInt32()
+		return int32(n), err
+	}
+	n, err := p.readUInt64()
+	return int64(n), err
+}
+
+func encodeVector256(value interface{}) ([]byte, error) {
+	items, ok := value.([]interface{})
+	if !ok {
+		return nil, fmt.Errorf("expected array of hashes, got %v", value)
+	}
+	out := make([]byte, 0, len(items)*32)
+	for _, item := range items {
+		b, err := toHex(item, 32)
+		if err != nil {
+			return nil, err
+		}
+		out = append(out, b...)
+	}
+	return out, nil
+}
+
+func decodeVector256(b []byte) (interface{}, error) {
+	if len(b)%32 != 0 {
+		return nil, fmt.Errorf("invalid Vector256 length: %d", len(b))
+	}
+	items := make([]interface{}, 0, len(b)/32)
+	for i := 0; i < len(b); i += 32 {
+		items = append(items, hexUpper(b[i:i+32]))
+	}
+	return items, nil
+}
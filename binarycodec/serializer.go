@@ -0,0 +1,72 @@
+package binarycodec
+
+import (
+	"bytes"
+	"encoding/binary"
+	"fmt"
+)
+
+// maxLengthPrefix is the largest length a variable length field may have.
+const maxLengthPrefix = 918744
+
+// binarySerializer accumulates serialized fields.
+type binarySerializer struct {
+	bytes.Buffer
+}
+
+func (s *binarySerializer) writeUInt16(v uint16) {
+	var b [2]byte
+	binary.BigEndian.PutUint16(b[:], v)
+	s.Write(b[:])
+}
+
+func (s *binarySerializer) writeUInt32(v uint32) {
+	var b [4]byte
+	binary.BigEndian.PutUint32(b[:], v)
+	s.Write(b[:])
+}
+
+func (s *binarySerializer) writeUInt64(v uint64) {
+	var b [8]byte
+	binary.BigEndian.PutUint64(b[:], v)
+	s.Write(b[:])
+}
+
+// writeLengthPrefix writes the length of a variable length field.
+func (s *binarySerializer) writeLengthPrefix(n int) error {
+	switch {
+	case n <= 192:
+		s.WriteByte(byte(n))
+	case n <= 12480:
+		n -= 193
+		s.WriteByte(byte(193 + n>>8))
+		s.WriteByte(byte(n))
+	case n <= maxLengthPrefix:
+		n -= 12481
+		s.WriteByte(byte(241 + n>>16))
+		s.WriteByte(byte(n >> 8))
+		s.WriteByte(byte(n))
+	default:
+		return fmt.Errorf("variable length field too long: %d bytes", n)
+	}
+	return nil
+}
+
+// writeVL writes a length prefixed value.
+func (s *binarySerializer) writeVL(value []byte) error {
+	if err := s.writeLengthPrefix(len(value)); err != nil {
+		return err
+	}
+	s.Write(value)
+	return nil
+}
+
+// EncodeVL prefixes value with its length, as variable length fields are
+// serialized.
+func EncodeVL(value []byte) ([]byte, error) {
+	var s binarySerializer
+	if err := s.writeVL(value); err != nil {
+		return nil, err
+	}
+	return s.Bytes(), nil
+}
@@ -0,0 +1,255 @@
+package binarycodec
+
+import (
+	"fmt"
+	"sort"
+)
+
+const (
+	objectEndMarker = 0xE1
+	arrayEndMarker  = 0xF1
+)
+
+// encodeObject serializes the fields of obj in canonical order. Keys that
+// are not serialized fields, such as "hash" or "date" in API responses,
+// are ignored. With signingOnly set, top-level fields that are not part of
+// the signed data are left out; nested objects are always encoded whole,
+// as rippled signs them.
+func encodeObject(s *binarySerializer, obj map[string]interface{}, signingOnly bool, d *Definitions) error {
+	fields := make([]*fieldInstance, 0, len(obj))
+	for name, value := range obj {
+		field, ok := d.fields[name]
+		if !ok || !field.IsSerialized || value == nil {
+			continue
+		}
+		if signingOnly && !field.IsSigningField {
+			continue
+		}
+		fields = append(fields, field)
+	}
+	sort.Slice(fields, func(i, j int) bool {
+		return fields[i].ordinal() < fields[j].ordinal()
+	})
+
+	for _, field := range fields {
+		s.Write(field.header())
+		if err := encodeField(s, field, obj[field.Name], d); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func encodeField(s *binarySerializer, field *fieldInstance, value interface{}, d *Definitions) error {
+	if field.IsVLEncoded {
+		var inner binarySerializer
+		if err := encodeValue(&inner, field, value, d); err != nil {
+			return err
+		}
+		return s.writeVL(inner.Bytes())
+	}
+	return encodeValue(s, field, value, d)
+}
+
+func encodeValue(s *binarySerializer, field *fieldInstance, value interface{}, d *Definitions) error {
+	var b []byte
+	var err error
+	switch field.Type {
+	case "UInt8":
+		return encodeUInt(s, field, value, 8, d)
+	case "UInt16":
+		return encodeUInt(s, field, value, 16, d)
+	case "UInt32":
+		return encodeUInt(s, field, value, 32, d)
+	case "UInt64":
+		return encodeUInt(s, field, value, 64, d)
+	case "Int32":
+		return encodeInt(s, field, value, 32)
+	case "Int64":
+		return encodeInt(s, field, value, 64)
+	case "Hash128", "Hash160", "Hash192", "Hash256", "UInt96", "UInt384", "UInt512":
+		b, err = toHex(value, fixedWidths[field.Type])
+	case "Blob":
+		b, err = toHex(value, -1)
+	case "AccountID":
+		b, err = encodeAccountID(value)
+	case "Currency":
+		b, err = encodeCurrency(value)
+	case "Vector256":
+		b, err = encodeVector256(value)
+	case "Issue":
+		b, err = encodeIssue(value)
+	case "XChainBridge":
+		b, err = encodeXChainBridge(value)
+	case "Amount":
+		err = encodeAmount(s, value)
+	case "Number":
+		err = encodeNumber(s, value)
+	case "PathSet":
+		err = encodePathSet(s, value)
+	case "STObject":
+		obj, ok := value.(map[string]interface{})
+		if !ok {
+			return fmt.Errorf("%s: expected object, got %v", field.Name, value)
+		}
+		if err = encodeObject(s, obj, false, d); err == nil {
+			s.WriteByte(objectEndMarker)
+		}
+	case "STArray":
+		err = encodeArray(s, field, value, d)
+	default:
+		return fmt.Errorf("%s: unsupported type %s", field.Name, field.Type)
+	}
+	if err != nil {
+		return fmt.Errorf("%s: %w", field.Name, err)
+	}
+	s.Write(b)
+	return nil
+}
+
+// encodeArray serializes an array of single-key objects such as
+// [{"Memo": {...}}], each wrapping an STObject field.
+func encodeArray(s *binarySerializer, field *fieldInstance, value interface{}, d *Definitions) error {
+	items, ok := value.([]interface{})
+	if !ok {
+		return fmt.Errorf("expected array, got %v", value)
+	}
+	for _, item := range items {
+		wrapper, ok := item.(map[string]interface{})
+		if !ok || len(wrapper) != 1 {
+			return fmt.Errorf("array element must be an object with a single field: %v", item)
+		}
+		for name, inner := range wrapper {
+			innerField, ok := d.fields[name]
+			if !ok || !innerField.IsSerialized {
+				return fmt.Errorf("unknown field %s", name)
+			}
+			s.Write(innerField.header())
+			if err := encodeField(s, innerField, inner, d); err != nil {
+				return err
+			}
+		}
+	}
+	s.WriteByte(arrayEndMarker)
+	return nil
+}
+
+// decodeObject reads fields until the end of the data or, for nested
+// objects, until the object end marker.
+func decodeObject(p *binaryParser, nested bool, d *Definitions) (map[string]interface{}, error) {
+	obj := make(map[string]interface{})
+	for !p.end() {
+		field, err := p.readField(d)
+		if err != nil {
+			return nil, err
+		}
+		if field.Type == "STObject" && field.Nth == 1 {
+			if !nested {
+				return nil, fmt.Errorf("unexpected object end marker")
+			}
+			return obj, nil
+		}
+		value, err := decodeField(p, field, d)
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", field.Name, err)
+		}
+		obj[field.Name] = value
+	}
+	if nested {
+		return nil, errUnexpectedEnd
+	}
+	return obj, nil
+}
+
+func decodeField(p *binaryParser, field *fieldInstance, d *Definitions) (interface{}, error) {
+	if field.IsVLEncoded {
+		n, err := p.readLengthPrefix()
+		if err != nil {
+			return nil, err
+		}
+		b, err := p.read(n)
+		if err != nil {
+			return nil, err
+		}
+		switch field.Type {
+		case "Blob":
+			return hexUpper(b), nil
+		case "AccountID":
+			return decodeAccountID(b)
+		case "Vector256":
+			return decodeVector256(b)
+		}
+		inner := &binaryParser{data: b}
+		value, err := decodeValue(inner, field, d)
+		if err == nil && !inner.end() {
+			err = fmt.Errorf("%d unexpected trailing bytes", len(b)-inner.pos)
+		}
+		return value, err
+	}
+	return decodeValue(p, field, d)
+}
+
+func decodeValue(p *binaryParser, field *fieldInstance, d *Definitions) (interface{}, error) {
+	switch field.Type {
+	case "UInt8":
+		return decodeUInt(p, field, 8, d)
+	case "UInt16":
+		return decodeUInt(p, field, 16, d)
+	case "UInt32":
+		return decodeUInt(p, field, 32, d)
+	case "UInt64":
+		return decodeUInt(p, field, 64, d)
+	case "Int32":
+		return decodeInt(p, 32)
+	case "Int64":
+		return decodeInt(p, 64)
+	case "Hash128", "Hash160", "Hash192", "Hash256", "UInt96", "UInt384", "UInt512":
+		b, err := p.read(fixedWidths[field.Type])
+		if err != nil {
+			return nil, err
+		}
+		return hexUpper(b), nil
+	case "AccountID":
+		return readAccountID(p)
+	case "Currency":
+		b, err := p.read(20)
+		if err != nil {
+			return nil, err
+		}
+		return decodeCurrency(b)
+	case "Issue":
+		return decodeIssue(p)
+	case "XChainBridge":
+		return decodeXChainBridge(p)
+	case "Amount":
+		return decodeAmount(p)
+	case "Number":
+		return decodeNumber(p)
+	case "PathSet":
+		return decodePathSet(p)
+	case "STObject":
+		return decodeObject(p, true, d)
+	case "STArray":
+		return decodeArray(p, d)
+	default:
+		return nil, fmt.Errorf("unsupported type %s", field.Type)
+	}
+}
+
+func decodeArray(p *binaryParser, d *Definitions) (interface{}, error) {
+	items := []interface{}{}
+	for {
+		field, err := p.readField(d)
+		if err != nil {
+			return nil, err
+		}
+		if field.Type == "STArray" && field.Nth == 1 {
+			return items, nil
+		}
+		value, err := decodeField(p, field, d)
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", field.Name, err)
+		}
+		items = append(items, map[string]interface{}{field.Name: value})
+	}
+}
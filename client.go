@@ -26,15 +26,33 @@ type ClientConfig struct {
 	WriteTimeout       time.Duration // Default is 60 seconds
 	HeartbeatInterval  time.Duration // Default is 5 seconds
 	QueueCapacity      int           // Default is 128
+	FailoverURLs       []string      // Servers tried in order after URL
+	LedgerTimeout      time.Duration // Default is 0 (watchdog disabled)
+	WatchdogInterval   time.Duration // Default is 10 seconds
+	ReconnectBackoff   time.Duration // Default is 1 second, doubled up to 1 minute
+	ReconnectAttempts  int           // Default is 0 (retry until connected)
 }
 
+// maxReconnectBackoff caps the delay between reconnection attempts.
+const maxReconnectBackoff = time.Minute
+
+// Client is a websocket client for a rippled or clio server.
+//
+// The exported Stream* channels are created once by NewClient and stay the
+// same for the lifetime of the Client. Reconnect replaces the underlying
+// websocket connection but keeps delivering into the same channels, so a
+// goroutine reading from client.StreamLedger receives one continuous feed
+// across reconnects. The channels are closed only by Close.
 type Client struct {
 	config              ClientConfig
 	connection          *websocket.Conn
-	heartbeatDone       chan bool
-	handlerDone         chan bool
+	urlIndex            int
+	done                chan struct{}
+	shutdown            chan struct{}
 	closed              bool
+	health              health
 	mutex               sync.Mutex
+	reconnectMutex      sync.Mutex
 	wg                  sync.WaitGroup
 	response            *http.Response
 	StreamLedger        chan []byte
@@ -47,11 +65,15 @@ type Client struct {
 	StreamServer        chan []byte
 	StreamDefault       chan []byte
 	StreamSubscriptions map[string]bool
-	requestQueue        map[string](chan<- BaseResponse)
+	requestQueue        map[string](chan<- []byte)
 	nextId              int
 	err                 error
 }
 
+// ErrClientClosed is returned when a connection is requested on a Client
+// that has been shut down with Close.
+var ErrClientClosed = errors.New("client is closed")
+
 func (config *ClientConfig) Validate() error {
 	if len(config.URL) == 0 {
 		return errors.New("cannot create a new connection with an empty URL")
@@ -66,6 +88,23 @@ func (config *ClientConfig) Validate() error {
 	if config.HeartbeatInterval < 0*time.Second || config.HeartbeatInterval >= 1*time.Hour {
 		return fmt.Errorf("connection heartbeat interval out of bounds: %d", config.HeartbeatInterval)
 	}
+	if config.LedgerTimeout < 0*time.Second || config.LedgerTimeout >= 1*time.Hour {
+		return fmt.Errorf("watchdog ledger timeout out of bounds: %d", config.LedgerTimeout)
+	}
+	if config.WatchdogInterval < 0*time.Second || config.WatchdogInterval >= 1*time.Hour {
+		return fmt.Errorf("watchdog interval out of bounds: %d", config.WatchdogInterval)
+	}
+	if config.ReconnectBackoff < 0*time.Second || config.ReconnectBackoff > maxReconnectBackoff {
+		return fmt.Errorf("reconnect backoff out of bounds: %d", config.ReconnectBackoff)
+	}
+	if config.ReconnectAttempts < 0 {
+		return fmt.Errorf("reconnect attempts out of bounds: %d", config.ReconnectAttempts)
+	}
+	for _, url := range config.FailoverURLs {
+		if len(url) == 0 {
+			return errors.New("cannot fail over to an empty URL")
+		}
+	}
 
 	return nil
 }
@@ -84,6 +123,12 @@ func NewClient(config ClientConfig) *Client {
 	if config.QueueCapacity == 0 {
 		config.QueueCapacity = 128
 	}
+	if config.WatchdogInterval == 0*time.Second {
+		config.WatchdogInterval = 10 * time.Second
+	}
+	if config.ReconnectBackoff == 0*time.Second {
+		config.ReconnectBackoff = 1 * time.Second
+	}
 
 	if err := config.Validate(); err != nil {
 		panic(err)
@@ -91,8 +136,7 @@ func NewClient(config ClientConfig) *Client {
 
 	client := &Client{
 		config:              config,
-		heartbeatDone:       make(chan bool),
-		handlerDone:         make(chan bool),
+		shutdown:            make(chan struct{}),
 		StreamLedger:        make(chan []byte, config.QueueCapacity),
 		StreamTransaction:   make(chan []byte, config.QueueCapacity),
 		StreamValidation:    make(chan []byte, config.QueueCapacity),
@@ -103,22 +147,45 @@ func NewClient(config ClientConfig) *Client {
 		StreamServer:        make(chan []byte, config.QueueCapacity),
 		StreamDefault:       make(chan []byte, config.QueueCapacity),
 		StreamSubscriptions: make(map[string]bool),
-		requestQueue:        make(map[string](chan<- BaseResponse)),
+		requestQueue:        make(map[string](chan<- []byte)),
 		nextId:              0,
 	}
 
 	_, err := client.NewConnection()
 	if err != nil {
-		log.Println("WS connection error:", client.config.URL, err)
+		log.Println("WS connection error:", client.URL(), err)
+	}
+
+	if config.LedgerTimeout > 0 {
+		go client.watchdog()
 	}
 	return client
 }
 
+// URL returns the address of the server the Client is currently connected
+// to, or will connect to next.
+func (c *Client) URL() string {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	return c.url()
+}
+
+func (c *Client) url() string {
+	if c.urlIndex == 0 {
+		return c.config.URL
+	}
+	return c.config.FailoverURLs[c.urlIndex-1]
+}
+
 func (c *Client) NewConnection() (*websocket.Conn, error) {
 	c.mutex.Lock()
 	defer c.mutex.Unlock()
 
-	conn, r, err := websocket.DefaultDialer.Dial(c.config.URL, nil)
+	if c.closed {
+		return nil, ErrClientClosed
+	}
+
+	conn, r, err := websocket.DefaultDialer.Dial(c.url(), nil)
 	if err != nil {
 		c.err = err
 		return nil, err
@@ -126,62 +193,102 @@ func (c *Client) NewConnection() (*websocket.Conn, error) {
 	defer r.Body.Close()
 	c.connection = conn
 	c.response = r
-	c.closed = false
+	c.done = make(chan struct{})
+	c.health.reset()
 
 	// Set connection handlers and heartbeat
 	c.connection.SetReadDeadline(time.Now().Add(c.config.ReadTimeout))
 	c.connection.SetWriteDeadline(time.Now().Add(c.config.WriteTimeout))
 	c.connection.SetPongHandler(c.handlePong)
 	c.wg.Add(2)
-	go c.handleResponse()
-	go c.heartbeat()
+	go c.handleResponse(conn, c.done)
+	go c.heartbeat(c.done)
 	return c.connection, nil
 }
 
+// Reconnect replaces the websocket connection with a new one and restores
+// stream subscriptions. Stream channels are left open, so consumers keep
+// reading from the same channels they were reading from before.
+//
+// Failed attempts are retried after ReconnectBackoff, doubling up to a
+// minute, moving on to the next of FailoverURLs if any. Reconnect returns
+// the last error after ReconnectAttempts attempts, or ErrClientClosed if the
+// Client is closed while it waits.
 func (c *Client) Reconnect() error {
-	// Close old websocket connection
-	c.Close()
-
-	// Wait for all goroutines to finish
-	c.wg.Wait()
+	return c.reconnect(nil, false)
+}
 
-	// Recreate stream channels and clean up request queue
-	c.mutex.Lock()
-	c.StreamLedger = make(chan []byte, c.config.QueueCapacity)
-	c.StreamTransaction = make(chan []byte, c.config.QueueCapacity)
-	c.StreamValidation = make(chan []byte, c.config.QueueCapacity)
-	c.StreamManifest = make(chan []byte, c.config.QueueCapacity)
-	c.StreamPeerStatus = make(chan []byte, c.config.QueueCapacity)
-	c.StreamConsensus = make(chan []byte, c.config.QueueCapacity)
-	c.StreamPathFind = make(chan []byte, c.config.QueueCapacity)
-	c.StreamServer = make(chan []byte, c.config.QueueCapacity)
-	c.StreamDefault = make(chan []byte, c.config.QueueCapacity)
+// reconnect replaces the connection whose goroutines stop on done, moving on
+// to the next server first if failover is set. It does nothing if that
+// connection has already been replaced, so a read error or a failed watchdog
+// check seen on an old connection never tears down a fresh one. A nil done
+// replaces whatever connection is current.
+func (c *Client) reconnect(done chan struct{}, failover bool) error {
+	c.reconnectMutex.Lock()
+	defer c.reconnectMutex.Unlock()
 
-	// Clean up pending requests to prevent goroutine leaks
-	for id, ch := range c.requestQueue {
-		close(ch)
-		delete(c.requestQueue, id)
+	if done != nil && done != c.currentDone() {
+		return nil
+	}
+	if failover {
+		c.nextURL()
 	}
-	c.mutex.Unlock()
+
+	// Close old websocket connection and wait for its goroutines to finish
+	c.disconnect()
+	c.wg.Wait()
 
 	// Create a new websocket connection
-	_, err := c.NewConnection()
-	if err != nil {
-		log.Println("WS reconnection error:", c.config.URL, err)
-		return err
+	backoff := c.config.ReconnectBackoff
+	for attempt := 1; ; attempt++ {
+		_, err := c.NewConnection()
+		if err == nil {
+			break
+		}
+		if errors.Is(err, ErrClientClosed) {
+			return err
+		}
+		log.Println("WS reconnection error:", c.URL(), err)
+		if c.config.ReconnectAttempts > 0 && attempt >= c.config.ReconnectAttempts {
+			return err
+		}
+		c.nextURL()
+
+		select {
+		case <-c.shutdown:
+			return ErrClientClosed
+		case <-time.After(backoff):
+		}
+		backoff *= 2
+		if backoff > maxReconnectBackoff {
+			backoff = maxReconnectBackoff
+		}
 	}
 
 	// Re-subscribe xrpl streams
-	_, err = c.Subscribe(c.Subscriptions())
-	if err != nil {
-		log.Println("WS stream subscription error:", err)
+	subs := c.Subscriptions()
+	if len(subs) > 0 {
+		_, err := c.Subscribe(subs)
+		if err != nil {
+			log.Println("WS stream subscription error:", err)
+		}
 	}
 	return nil
 }
 
+// currentDone returns the done channel of the current connection.
+func (c *Client) currentDone() chan struct{} {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	return c.done
+}
+
 func (c *Client) Ping(message []byte) error {
 	c.mutex.Lock()
 	defer c.mutex.Unlock()
+	if c.connection == nil {
+		return errors.New("not connected")
+	}
 	// log.Println("PING:", string(message))
 	newDeadline := time.Now().Add(c.config.WriteTimeout)
 	if err := c.connection.WriteControl(websocket.PingMessage, message, newDeadline); err != nil {
@@ -193,8 +300,8 @@ func (c *Client) Ping(message []byte) error {
 // Returns incremental ID that may be used as request ID for websocket requests
 func (c *Client) NextID() string {
 	c.mutex.Lock()
+	defer c.mutex.Unlock()
 	c.nextId++
-	c.mutex.Unlock()
 	return strconv.Itoa(c.nextId)
 }
 
@@ -208,23 +315,54 @@ func (c *Client) Subscriptions() []string {
 	return subs
 }
 
+// Stream returns the channel on which messages of the requested StreamType
+// are delivered. The returned channel is stable across Reconnect and is
+// closed only when the Client is closed. Unknown stream types map to
+// StreamDefault.
+func (c *Client) Stream(streamType string) <-chan []byte {
+	switch streamType {
+	case StreamTypeLedger:
+		return c.StreamLedger
+	case StreamTypeTransaction, StreamTypeTransactionsProposed:
+		return c.StreamTransaction
+	case StreamTypeValidations:
+		return c.StreamValidation
+	case StreamTypeManifests:
+		return c.StreamManifest
+	case StreamTypePeerStatus:
+		return c.StreamPeerStatus
+	case StreamTypeConsensus:
+		return c.StreamConsensus
+	case StreamTypePathFind:
+		return c.StreamPathFind
+	case StreamTypeServer:
+		return c.StreamServer
+	default:
+		return c.StreamDefault
+	}
+}
+
+// Close shuts down the websocket connection and closes all stream channels.
+// A closed Client cannot be reconnected.
 func (c *Client) Close() error {
 	c.mutex.Lock()
-	defer c.mutex.Unlock()
+	if c.closed {
+		c.mutex.Unlock()
+		return ErrClientClosed
+	}
 	c.closed = true
+	close(c.shutdown)
+	c.mutex.Unlock()
 
-	// Signal both goroutines to stop
-	select {
-	case c.heartbeatDone <- true:
-	default:
-	}
+	// A Reconnect waiting between attempts returns once shutdown is closed.
+	c.reconnectMutex.Lock()
+	defer c.reconnectMutex.Unlock()
 
-	select {
-	case c.handlerDone <- true:
-	default:
-	}
+	err := c.disconnect()
 
-	// Close all stream channels to prevent blocking
+	// Stream channels are written to only by the response handler, so they
+	// may be closed safely once it has returned.
+	c.wg.Wait()
 	close(c.StreamLedger)
 	close(c.StreamTransaction)
 	close(c.StreamValidation)
@@ -234,6 +372,26 @@ func (c *Client) Close() error {
 	close(c.StreamPathFind)
 	close(c.StreamServer)
 	close(c.StreamDefault)
+	return err
+}
+
+// disconnect closes the current websocket connection and signals its
+// handler and heartbeat goroutines to stop. Stream channels are left open.
+func (c *Client) disconnect() error {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	if c.connection == nil {
+		return nil
+	}
+
+	// Signal both goroutines to stop
+	select {
+	case <-c.done:
+		return nil
+	default:
+		close(c.done)
+	}
 
 	// Clean up pending requests to prevent goroutine leaks
 	for id, ch := range c.requestQueue {
@@ -244,12 +402,10 @@ func (c *Client) Close() error {
 	err := c.connection.WriteMessage(websocket.CloseMessage, websocket.FormatCloseMessage(websocket.CloseNormalClosure, ""))
 	if err != nil {
 		log.Println("WS write error:", err)
-		return err
 	}
-	err = c.connection.Close()
-	if err != nil {
-		log.Println("WS close error:", err)
-		return err
+	if cerr := c.connection.Close(); cerr != nil {
+		log.Println("WS close error:", cerr)
+		return cerr
 	}
-	return nil
+	return err
 }
@@ -0,0 +1,224 @@
+package xrpl
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/gorilla/websocket"
+)
+
+// testServer is a websocket server that answers server_state with a fixed
+// state and an advancing validated ledger, and every other command with an
+// empty result.
+type testServer struct {
+	*httptest.Server
+	state       string
+	ledger      uint64
+	connections int32
+	mutex       sync.Mutex
+	conns       []*websocket.Conn
+}
+
+func newTestServer(t *testing.T, state string) *testServer {
+	s := &testServer{state: state}
+	upgrader := websocket.Upgrader{}
+	s.Server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		conn, err := upgrader.Upgrade(w, r, nil)
+		if err != nil {
+			return
+		}
+		atomic.AddInt32(&s.connections, 1)
+		s.mutex.Lock()
+		s.conns = append(s.conns, conn)
+		s.mutex.Unlock()
+		defer conn.Close()
+		for {
+			_, message, err := conn.ReadMessage()
+			if err != nil {
+				return
+			}
+			var req struct {
+				Id      string `json:"id"`
+				Command string `json:"command"`
+			}
+			if err := json.Unmarshal(message, &req); err != nil {
+				return
+			}
+			result := map[string]interface{}{}
+			if req.Command == "server_state" {
+				result["state"] = map[string]interface{}{
+					"server_state":     s.state,
+					"validated_ledger": map[string]interface{}{"seq": atomic.AddUint64(&s.ledger, 1)},
+				}
+			}
+			res, _ := json.Marshal(map[string]interface{}{"id": req.Id, "type": "response", "status": "success", "result": result})
+			if err := conn.WriteMessage(websocket.TextMessage, res); err != nil {
+				return
+			}
+		}
+	}))
+	t.Cleanup(s.Close)
+	return s
+}
+
+func (s *testServer) url() string {
+	return "ws" + strings.TrimPrefix(s.URL, "http")
+}
+
+func (s *testServer) count() int {
+	return int(atomic.LoadInt32(&s.connections))
+}
+
+// drop closes every connection from the server side.
+func (s *testServer) drop() {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	for _, conn := range s.conns {
+		conn.Close()
+	}
+	s.conns = nil
+}
+
+// eventually waits up to a second for cond to hold.
+func eventually(t *testing.T, what string, cond func() bool) {
+	t.Helper()
+	deadline := time.Now().Add(time.Second)
+	for !cond() {
+		if time.Now().After(deadline) {
+			t.Fatalf("timed out waiting for %s", what)
+		}
+		time.Sleep(5 * time.Millisecond)
+	}
+}
+
+func TestReconnect(t *testing.T) {
+	s := newTestServer(t, "full")
+	c := NewClient(ClientConfig{URL: s.url(), ReconnectBackoff: 10 * time.Millisecond})
+	defer c.Close()
+	if _, err := c.Subscribe([]string{StreamTypeLedger}); err != nil {
+		t.Fatal(err)
+	}
+
+	old := c.currentDone()
+	if err := c.Reconnect(); err != nil {
+		t.Fatal(err)
+	}
+	if s.count() != 2 || c.currentDone() == old {
+		t.Fatalf("%d connections after Reconnect", s.count())
+	}
+	if subs := c.Subscriptions(); len(subs) != 1 || subs[0] != StreamTypeLedger {
+		t.Errorf("subscriptions %v after Reconnect", subs)
+	}
+
+	// A reconnect for the replaced connection, as from its read handler or
+	// from a watchdog check that raced with Reconnect, leaves the new one.
+	fresh := c.currentDone()
+	if err := c.reconnect(old, true); err != nil {
+		t.Fatal(err)
+	}
+	if s.count() != 2 || c.currentDone() != fresh || c.URL() != s.url() {
+		t.Errorf("stale reconnect replaced the connection: %d connections", s.count())
+	}
+
+	// A read error reconnects once.
+	s.drop()
+	eventually(t, "a reconnect", func() bool { return s.count() == 3 })
+	time.Sleep(50 * time.Millisecond)
+	if s.count() != 3 {
+		t.Errorf("%d connections after a read error", s.count())
+	}
+	if _, err := c.Request(BaseRequest{"command": "ping"}); err != nil {
+		t.Error(err)
+	}
+}
+
+func TestWatchdog(t *testing.T) {
+	unsynced := newTestServer(t, "connected")
+	synced := newTestServer(t, "full")
+	c := NewClient(ClientConfig{
+		URL:              unsynced.url(),
+		FailoverURLs:     []string{synced.url()},
+		LedgerTimeout:    time.Second,
+		WatchdogInterval: 20 * time.Millisecond,
+	})
+	defer c.Close()
+
+	eventually(t, "a failover", func() bool { return c.URL() == synced.url() })
+	eventually(t, "a synced server", func() bool { return c.ServerState() == "full" })
+	if !c.Healthy() {
+		t.Error("synced server is unhealthy")
+	}
+	// The synced server is kept.
+	time.Sleep(100 * time.Millisecond)
+	if c.URL() != synced.url() || synced.count() != 1 {
+		t.Errorf("left the synced server: %s, %d connections", c.URL(), synced.count())
+	}
+	if ledger, _ := c.LastLedger(); ledger == 0 {
+		t.Error("no ledger seen")
+	}
+}
+
+func TestCloseDuringReconnect(t *testing.T) {
+	s := newTestServer(t, "full")
+	for i := 0; i < 20; i++ {
+		c := NewClient(ClientConfig{URL: s.url(), ReconnectBackoff: time.Millisecond})
+		var wg sync.WaitGroup
+		wg.Add(3)
+		go func() {
+			defer wg.Done()
+			if err := c.Reconnect(); err != nil && !errors.Is(err, ErrClientClosed) {
+				t.Error(err)
+			}
+		}()
+		go func() {
+			defer wg.Done()
+			c.Failover()
+		}()
+		go func() {
+			defer wg.Done()
+			if err := c.Close(); err != nil && !errors.Is(err, ErrClientClosed) {
+				t.Error(err)
+			}
+		}()
+		wg.Wait()
+
+		if err := c.Close(); !errors.Is(err, ErrClientClosed) {
+			t.Errorf("closed twice: %v", err)
+		}
+		if err := c.Reconnect(); !errors.Is(err, ErrClientClosed) {
+			t.Errorf("reconnected a closed client: %v", err)
+		}
+		if _, ok := <-c.StreamLedger; ok {
+			t.Error("stream left open")
+		}
+	}
+}
+
+func TestCloseWhileRetrying(t *testing.T) {
+	// Nothing listens on the URL, so Reconnect waits between attempts.
+	s := newTestServer(t, "full")
+	url := s.url()
+	s.Close()
+	c := NewClient(ClientConfig{URL: url, ReconnectBackoff: time.Minute})
+	result := make(chan error)
+	go func() { result <- c.Reconnect() }()
+	time.Sleep(50 * time.Millisecond)
+	if err := c.Close(); err != nil {
+		t.Error(err)
+	}
+	select {
+	case err := <-result:
+		if !errors.Is(err, ErrClientClosed) {
+			t.Errorf("Reconnect returned %v", err)
+		}
+	case <-time.After(time.Second):
+		t.Fatal("Reconnect kept retrying after Close")
+	}
+}
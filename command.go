@@ -3,9 +3,12 @@ package xrpl
 import (
 	"encoding/json"
 	"errors"
+	"fmt"
+	"strconv"
 	"time"
 
 	"github.com/gorilla/websocket"
+	"github.com/xrpscan/xrpl-go/models"
 )
 
 func (c *Client) Subscribe(streams []string) (BaseResponse, error) {
@@ -57,20 +60,86 @@ func (c *Client) Unsubscribe(streams []string) (BaseResponse, error) {
 //		"ledger_index": "current",
 //	}
 //
-//	err := client.Request(req, func(){})
+//	res, err := client.Request(req)
 func (c *Client) Request(req BaseRequest) (BaseResponse, error) {
+	data, err := c.RequestRaw(req)
+	if err != nil {
+		return nil, err
+	}
+	var res BaseResponse
+	if err := json.Unmarshal(data, &res); err != nil {
+		return nil, err
+	}
+	return res, nil
+}
+
+// RequestRaw sends a websocket request like Request, but returns the
+// response as received, without decoding it.
+func (c *Client) RequestRaw(req BaseRequest) (json.RawMessage, error) {
 	requestId := c.NextID()
 	req["id"] = requestId
 	data, err := json.Marshal(req)
 	if err != nil {
 		return nil, err
 	}
+	return c.send(requestId, data)
+}
+
+// RequestInto sends a typed request, such as a struct from the methods
+// package with Command set, and decodes the response straight into res. The
+// request ID is assigned by the client, replacing any Id set on req. Requests
+// with a Validate() error method are validated before being sent. Error
+// responses are returned as *RequestError.
+//
+// Example usage:
+//
+//	req := methods.TxRequest{Transaction: hash}
+//	req.Command = "tx"
+//	var res methods.TxResponse
+//	err := client.RequestInto(req, &res)
+func (c *Client) RequestInto(req interface{}, res interface{}) error {
+	if v, ok := req.(interface{ Validate() error }); ok {
+		if err := v.Validate(); err != nil {
+			return err
+		}
+	}
+	data, err := json.Marshal(req)
+	if err != nil {
+		return err
+	}
+	var fields map[string]json.RawMessage
+	if err := json.Unmarshal(data, &fields); err != nil || fields == nil {
+		return errors.New("request must encode to a JSON object")
+	}
+
+	// Replace any request ID set by the caller, so that the response can be
+	// matched to this request
+	requestId := c.NextID()
+	fields["id"] = json.RawMessage(strconv.Quote(requestId))
+	data, err = json.Marshal(fields)
+	if err != nil {
+		return err
+	}
+
+	raw, err := c.send(requestId, data)
+	if err != nil {
+		return err
+	}
+	return decodeResponse(raw, res)
+}
 
-	ch := make(chan BaseResponse, 1)
+// send writes a request to the websocket and waits for the response with
+// the same request ID.
+func (c *Client) send(requestId string, data []byte) (json.RawMessage, error) {
+	ch := make(chan []byte, 1)
 
 	c.mutex.Lock()
+	if c.connection == nil {
+		c.mutex.Unlock()
+		return nil, errors.New("not connected")
+	}
 	c.requestQueue[requestId] = ch
-	err = c.connection.WriteMessage(websocket.TextMessage, data)
+	err := c.connection.WriteMessage(websocket.TextMessage, data)
 	if err != nil {
 		delete(c.requestQueue, requestId)
 		close(ch)
@@ -81,13 +150,58 @@ func (c *Client) Request(req BaseRequest) (BaseResponse, error) {
 
 	// Add timeout to prevent channel and goroutine leak
 	select {
-	case res := <-ch:
+	case res, ok := <-ch:
+		if !ok {
+			return nil, errors.New("connection closed")
+		}
 		return res, nil
 	case <-time.After(c.config.ReadTimeout):
 		c.mutex.Lock()
-		delete(c.requestQueue, requestId)
-		close(ch)
+		if _, ok := c.requestQueue[requestId]; ok {
+			delete(c.requestQueue, requestId)
+			close(ch)
+		}
 		c.mutex.Unlock()
 		return nil, errors.New("request timeout")
 	}
 }
+
+// RequestError is returned by typed request methods when the server responds
+// with status "error".
+type RequestError struct {
+	models.ErrorResponse
+}
+
+func (e *RequestError) Error() string {
+	if len(e.ErrorMessage) > 0 {
+		return fmt.Sprintf("%s: %s", e.ErrorResponse.Error, e.ErrorMessage)
+	}
+	return e.ErrorResponse.Error
+}
+
+// decodeResponse decodes a raw response into res, or returns a *RequestError
+// if the response status is "error".
+func decodeResponse(raw []byte, res interface{}) error {
+	fields, err := scanFields(raw, "status")
+	if err != nil {
+		return err
+	}
+	if rawString(fields[0]) == "error" {
+		// rippled sends the request id as given, a string for requests made
+		// by Client, and error_code as a number; ErrorResponse holds them as
+		// int and string.
+		var errRes struct {
+			models.ErrorResponse
+			Id        json.RawMessage `json:"id,omitempty"`
+			ErrorCode json.RawMessage `json:"error_code,omitempty"`
+		}
+		if err := json.Unmarshal(raw, &errRes); err != nil {
+			return err
+		}
+		reqErr := &RequestError{ErrorResponse: errRes.ErrorResponse}
+		reqErr.Id, _ = strconv.Atoi(rawString(errRes.Id))
+		reqErr.ErrorCode = rawString(errRes.ErrorCode)
+		return reqErr
+	}
+	return json.Unmarshal(raw, res)
+}
@@ -0,0 +1,259 @@
+// Package cryptoconditions implements the PREIMAGE-SHA-256 crypto-condition,
+// the only type the XRP Ledger supports, for the Condition of an
+// EscrowCreate and the Fulfillment of the EscrowFinish that releases it.
+// Conditions and fulfillments are DER encoded as specified by
+// draft-thomas-crypto-conditions-04, and passed around as uppercase hex.
+// https://xrpl.org/docs/concepts/payment-types/escrow#escrow-conditions
+package cryptoconditions
+
+import (
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"strings"
+)
+
+const (
+	// PreimageLength is the length of generated preimages.
+	PreimageLength = 32
+	// MaxFulfillmentLength is the largest fulfillment the XRP Ledger
+	// accepts, in bytes.
+	MaxFulfillmentLength = 256
+	// MaxConditionLength is the largest condition the XRP Ledger accepts,
+	// in bytes.
+	MaxConditionLength = 128
+)
+
+// ASN.1 tags of the PREIMAGE-SHA-256 type and its fields.
+const (
+	tagPreimageSha256 = 0xA0
+	tagPreimage       = 0x80
+	tagFingerprint    = 0x80
+	tagCost           = 0x81
+)
+
+var (
+	// ErrUnsupportedType is returned for conditions and fulfillments of
+	// types other than PREIMAGE-SHA-256.
+	ErrUnsupportedType = errors.New("unsupported crypto-condition type")
+
+	// ErrMismatch is returned when a fulfillment does not satisfy a
+	// condition.
+	ErrMismatch = errors.New("fulfillment does not match condition")
+)
+
+// PreimageSha256 is a fulfillment that reveals a preimage whose SHA-256
+// hash is the condition's fingerprint.
+type PreimageSha256 struct {
+	Preimage []byte
+}
+
+// Condition is a decoded condition.
+type Condition struct {
+	Fingerprint []byte
+	Cost        uint64
+}
+
+// Generate returns a fulfillment with a random 32 byte preimage and the
+// condition it satisfies. Keep the fulfillment secret until the escrow is
+// to be finished.
+func Generate() (fulfillment string, condition string, err error) {
+	preimage := make([]byte, PreimageLength)
+	if _, err := rand.Read(preimage); err != nil {
+		return "", "", err
+	}
+	f := &PreimageSha256{Preimage: preimage}
+	return f.Fulfillment(), f.Condition(), nil
+}
+
+// Fulfillment returns the DER encoded fulfillment.
+func (f *PreimageSha256) Fulfillment() string {
+	body := encodeTLV(tagPreimage, f.Preimage)
+	return hexUpper(encodeTLV(tagPreimageSha256, body))
+}
+
+// Condition returns the DER encoded condition the fulfillment satisfies.
+// Its cost is the length of the preimage.
+func (f *PreimageSha256) Condition() string {
+	fingerprint := sha256.Sum256(f.Preimage)
+	body := encodeTLV(tagFingerprint, fingerprint[:])
+	body = append(body, encodeTLV(tagCost, encodeUint(uint64(len(f.Preimage))))...)
+	return hexUpper(encodeTLV(tagPreimageSha256, body))
+}
+
+// DecodeFulfillment decodes a hex encoded PREIMAGE-SHA-256 fulfillment.
+func DecodeFulfillment(fulfillment string) (*PreimageSha256, error) {
+	b, err := hex.DecodeString(fulfillment)
+	if err != nil {
+		return nil, err
+	}
+	if len(b) > MaxFulfillmentLength {
+		return nil, fmt.Errorf("fulfillment is longer than %d bytes", MaxFulfillmentLength)
+	}
+	body, err := decodeType(b)
+	if err != nil {
+		return nil, err
+	}
+	preimage, rest, err := decodeTLV(body, tagPreimage)
+	if err != nil {
+		return nil, err
+	}
+	if len(rest) != 0 {
+		return nil, errors.New("trailing data in fulfillment")
+	}
+	return &PreimageSha256{Preimage: preimage}, nil
+}
+
+// DecodeCondition decodes a hex encoded PREIMAGE-SHA-256 condition.
+func DecodeCondition(condition string) (*Condition, error) {
+	b, err := hex.DecodeString(condition)
+	if err != nil {
+		return nil, err
+	}
+	if len(b) > MaxConditionLength {
+		return nil, fmt.Errorf("condition is longer than %d bytes", MaxConditionLength)
+	}
+	body, err := decodeType(b)
+	if err != nil {
+		return nil, err
+	}
+	fingerprint, rest, err := decodeTLV(body, tagFingerprint)
+	if err != nil {
+		return nil, err
+	}
+	if len(fingerprint) != sha256.Size {
+		return nil, errors.New("condition fingerprint must be 32 bytes")
+	}
+	cost, rest, err := decodeTLV(rest, tagCost)
+	if err != nil {
+		return nil, err
+	}
+	if len(rest) != 0 {
+		return nil, errors.New("trailing data in condition")
+	}
+	if !validUint(cost) {
+		return nil, errors.New("invalid condition cost")
+	}
+	c := &Condition{Fingerprint: fingerprint}
+	for _, b := range cost {
+		c.Cost = c.Cost<<8 | uint64(b)
+	}
+	return c, nil
+}
+
+// Validate checks that a hex encoded fulfillment satisfies a hex encoded
+// condition.
+func Validate(fulfillment string, condition string) error {
+	f, err := DecodeFulfillment(fulfillment)
+	if err != nil {
+		return err
+	}
+	if _, err := DecodeCondition(condition); err != nil {
+		return err
+	}
+	if !strings.EqualFold(f.Condition(), condition) {
+		return ErrMismatch
+	}
+	return nil
+}
+
+// EscrowFinishFee returns the transaction cost, in drops, of an
+// EscrowFinish that carries fulfillment: baseFee times 33, plus baseFee for
+// every 16 bytes of the fulfillment. At a base fee of 10 drops, that is 330
+// drops plus 10 per 16 bytes, so 350 drops for a 32 byte preimage.
+// https://xrpl.org/docs/references/protocol/transactions/types/escrowfinish
+func EscrowFinishFee(fulfillment string, baseFee uint64) (uint64, error) {
+	b, err := hex.DecodeString(fulfillment)
+	if err != nil {
+		return 0, err
+	}
+	return baseFee * (33 + uint64(len(b))/16), nil
+}
+
+// decodeType strips the PREIMAGE-SHA-256 tag and returns its contents.
+func decodeType(b []byte) ([]byte, error) {
+	if len(b) == 0 || b[0] != tagPreimageSha256 {
+		return nil, ErrUnsupportedType
+	}
+	body, rest, err := decodeTLV(b, tagPreimageSha256)
+	if err != nil {
+		return nil, err
+	}
+	if len(rest) != 0 {
+		return nil, errors.New("trailing data after crypto-condition")
+	}
+	return body, nil
+}
+
+// decodeTLV reads a DER tag, length and value from the start of b.
+func decodeTLV(b []byte, tag byte) (value []byte, rest []byte, err error) {
+	if len(b) < 2 || b[0] != tag {
+		return nil, nil, fmt.Errorf("expected DER tag %02X", tag)
+	}
+	length, n := int(b[1]), 2
+	if length&0x80 != 0 {
+		octets := length & 0x7F
+		if octets == 0 || octets > 2 || len(b) < 2+octets {
+			return nil, nil, errors.New("invalid DER length")
+		}
+		length = 0
+		for _, o := range b[2 : 2+octets] {
+			length = length<<8 | int(o)
+		}
+		if length < 0x80 || (octets == 2 && length < 0x100) {
+			return nil, nil, errors.New("DER length is not minimally encoded")
+		}
+		n += octets
+	}
+	if len(b) < n+length {
+		return nil, nil, errors.New("DER value is truncated")
+	}
+	return b[n : n+length], b[n+length:], nil
+}
+
+func encodeTLV(tag byte, value []byte) []byte {
+	b := []byte{tag}
+	switch n := len(value); {
+	case n < 0x80:
+		b = append(b, byte(n))
+	case n < 0x100:
+		b = append(b, 0x81, byte(n))
+	default:
+		b = append(b, 0x82, byte(n>>8), byte(n))
+	}
+	return append(b, value...)
+}
+
+// encodeUint encodes n as the contents of a DER INTEGER: minimal
+// big-endian two's complement, so values with the high bit set get a
+// leading zero.
+func encodeUint(n uint64) []byte {
+	b := []byte{byte(n)}
+	for n >>= 8; n > 0; n >>= 8 {
+		b = append([]byte{byte(n)}, b...)
+	}
+	if b[0]&0x80 != 0 {
+		b = append([]byte{0}, b...)
+	}
+	return b
+}
+
+// validUint reports whether b is the contents of a minimally encoded,
+// non-negative DER INTEGER that fits in a uint64.
+func validUint(b []byte) bool {
+	switch {
+	case len(b) == 0 || b[0]&0x80 != 0:
+		return false
+	case len(b) > 1 && b[0] == 0 && b[1]&0x80 == 0:
+		return false
+	case len(b) > 9 || (len(b) == 9 && b[0] != 0):
+		return false
+	}
+	return true
+}
+
+func hexUpper(b []byte) string {
+	return strings.ToUpper(hex.EncodeToString(b))
+}
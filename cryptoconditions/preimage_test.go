@@ -0,0 +1,155 @@
+package cryptoconditions
+
+import (
+	"bytes"
+	"errors"
+	"strings"
+	"testing"
+)
+
+// Known PREIMAGE-SHA-256 vectors. The empty preimage is the example of
+// draft-thomas-crypto-conditions-04 and of the EscrowCreate and
+// EscrowFinish examples on xrpl.org; "aaa" is from the five-bells-condition
+// test suite.
+var vectors = []struct {
+	preimage, fulfillment, condition string
+	cost                             uint64
+}{
+	{"", "A0028000", "A0258020E3B0C44298FC1C149AFBF4C8996FB92427AE41E4649B934CA495991B7852B855810100", 0},
+	{"aaa", "A0058003616161", "A02580209834876DCFB05CB167A5C24953EBA58C4AC89B1ADF57F28F2F9D09AF107EE8F0810103", 3},
+}
+
+func TestVectors(t *testing.T) {
+	for _, v := range vectors {
+		f := &PreimageSha256{Preimage: []byte(v.preimage)}
+		if got := f.Fulfillment(); got != v.fulfillment {
+			t.Errorf("%q: fulfillment %s, want %s", v.preimage, got, v.fulfillment)
+		}
+		if got := f.Condition(); got != v.condition {
+			t.Errorf("%q: condition %s, want %s", v.preimage, got, v.condition)
+		}
+
+		decoded, err := DecodeFulfillment(v.fulfillment)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !bytes.Equal(decoded.Preimage, []byte(v.preimage)) {
+			t.Errorf("%q: decoded preimage %q", v.preimage, decoded.Preimage)
+		}
+		c, err := DecodeCondition(v.condition)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if c.Cost != v.cost || hexUpper(c.Fingerprint) != v.condition[8:72] {
+			t.Errorf("%q: decoded condition %X cost %d", v.preimage, c.Fingerprint, c.Cost)
+		}
+		if err := Validate(v.fulfillment, v.condition); err != nil {
+			t.Errorf("%q: %v", v.preimage, err)
+		}
+		if err := Validate(strings.ToLower(v.fulfillment), strings.ToLower(v.condition)); err != nil {
+			t.Errorf("%q: lowercase: %v", v.preimage, err)
+		}
+	}
+
+	if err := Validate(vectors[0].fulfillment, vectors[1].condition); !errors.Is(err, ErrMismatch) {
+		t.Errorf("mismatch: got %v, want %v", err, ErrMismatch)
+	}
+}
+
+func TestGenerate(t *testing.T) {
+	fulfillment, condition, err := Generate()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := Validate(fulfillment, condition); err != nil {
+		t.Error(err)
+	}
+	f, err := DecodeFulfillment(fulfillment)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(f.Preimage) != PreimageLength {
+		t.Errorf("generated a %d byte preimage", len(f.Preimage))
+	}
+	again, _, err := Generate()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if again == fulfillment {
+		t.Error("generated the same preimage twice")
+	}
+}
+
+func TestDecodeRejects(t *testing.T) {
+	long := strings.Repeat("61", 300)
+	for _, fulfillment := range []string{
+		"",
+		"A002800",                     // odd length
+		"A1028000",                    // another type
+		"A00280",                      // truncated
+		"A00480000000",                // trailing data inside the type
+		"A002800000",                  // trailing data after the type
+		"A08102800000",                // non-minimal length of the type
+		"A004808101" + "61",           // non-minimal length of the preimage
+		"A0028100",                    // preimage with the wrong tag
+		"A0830000038000",              // three length octets
+		"A0820004" + "80026161",       // two octets for a length under 256
+		"A08201018201" + long[:2*253], // over 256 bytes
+	} {
+		if _, err := DecodeFulfillment(fulfillment); err == nil {
+			t.Errorf("decoded fulfillment %s", fulfillment)
+		}
+	}
+
+	condition := vectors[1].condition
+	for _, c := range []string{
+		"",
+		"A1" + condition[2:],                    // another type
+		condition + "00",                        // trailing data
+		"A0248020" + condition[8:72] + "8100",   // empty cost
+		"A026" + condition[4:74] + "020003",     // non-minimal cost
+		"A024801F" + condition[8:70] + "810103", // short fingerprint
+		"A0258120" + condition[8:72] + "810103", // fingerprint with the wrong tag
+		"A025" + condition[4:72] + "820103",     // cost with the wrong tag
+		"A025" + condition[4:72] + "8101FF",     // negative cost
+		"A08125" + condition[4:],                // non-minimal length
+	} {
+		if _, err := DecodeCondition(c); err == nil {
+			t.Errorf("decoded condition %s", c)
+		}
+	}
+
+	if err := Validate("A1028000", vectors[0].condition); !errors.Is(err, ErrUnsupportedType) {
+		t.Errorf("another type: got %v, want %v", err, ErrUnsupportedType)
+	}
+}
+
+func TestEscrowFinishFee(t *testing.T) {
+	f := &PreimageSha256{Preimage: bytes.Repeat([]byte{1}, PreimageLength)}
+	for _, test := range []struct {
+		fulfillment string
+		baseFee     uint64
+		fee         uint64
+	}{
+		// 330 drops at a base fee of 10, plus 10 for each 16 bytes of the
+		// 36 byte fulfillment of a 32 byte preimage.
+		{f.Fulfillment(), 10, 350},
+		{f.Fulfillment(), 12, 420},
+		{vectors[0].fulfillment, 10, 330},
+		{vectors[1].fulfillment, 10, 330},
+	} {
+		fee, err := EscrowFinishFee(test.fulfillment, test.baseFee)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if fee != test.fee {
+			t.Errorf("%s at %d: fee %d, want %d", test.fulfillment, test.baseFee, fee, test.fee)
+		}
+	}
+	if _, err := EscrowFinishFee("A0", 10); err != nil {
+		t.Error(err)
+	}
+	if _, err := EscrowFinishFee("XX", 10); err == nil {
+		t.Error("computed the fee of an invalid fulfillment")
+	}
+}
@@ -0,0 +1,68 @@
+package xrpl
+
+import (
+	"encoding/json"
+	"errors"
+
+	"github.com/xrpscan/xrpl-go/binarycodec"
+	"github.com/xrpscan/xrpl-go/methods"
+	"github.com/xrpscan/xrpl-go/models"
+)
+
+// CTID returns the concise transaction identifier (XLS-37) of a transaction
+// in the given ledger and position on this network.
+func (n Network) CTID(ledgerIndex uint64, transactionIndex uint64) (models.CTID, error) {
+	ctid := models.CTID{
+		LeadIn:           models.CTIDLeadIn,
+		LedgerIndex:      ledgerIndex,
+		TransactionIndex: transactionIndex,
+		NetworkId:        uint64(n),
+	}
+	return ctid, ctid.Validate()
+}
+
+// CTIDFromTxResult returns the CTID of a validated transaction returned by
+// the tx method, in JSON or binary form.
+func (n Network) CTIDFromTxResult(tx methods.TxResponseResult) (models.CTID, error) {
+	if !tx.Validated {
+		return models.CTID{}, errors.New("transaction is not validated")
+	}
+	if tx.LedgerIndex <= 0 {
+		return models.CTID{}, errors.New("transaction has no ledger_index")
+	}
+	if tx.MetaBlob != "" {
+		meta, err := binarycodec.Decode(tx.MetaBlob)
+		if err != nil {
+			return models.CTID{}, err
+		}
+		index, ok := meta["TransactionIndex"].(uint32)
+		if !ok {
+			return models.CTID{}, errors.New("transaction metadata has no TransactionIndex")
+		}
+		return n.CTID(uint64(tx.LedgerIndex), uint64(index))
+	}
+	// Every metadata object has a TransactionResult, so an empty one means
+	// the result carried no metadata and TransactionIndex is not known.
+	if tx.Meta.TransactionResult == "" {
+		return models.CTID{}, errors.New("transaction has no metadata")
+	}
+	return n.CTID(uint64(tx.LedgerIndex), uint64(tx.Meta.TransactionIndex))
+}
+
+// CTIDFromTransactionStream returns the CTID of a validated transaction
+// received on the transactions stream.
+func (n Network) CTIDFromTransactionStream(tx models.TransactionStream) (models.CTID, error) {
+	if !tx.Validated {
+		return models.CTID{}, errors.New("transaction is not validated")
+	}
+	var meta struct {
+		TransactionIndex *uint64
+	}
+	if err := json.Unmarshal(tx.Meta, &meta); err != nil {
+		return models.CTID{}, err
+	}
+	if meta.TransactionIndex == nil {
+		return models.CTID{}, errors.New("transaction metadata has no TransactionIndex")
+	}
+	return n.CTID(tx.LedgerIndex, *meta.TransactionIndex)
+}
@@ -0,0 +1,75 @@
+package xrpl
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/xrpscan/xrpl-go/methods"
+)
+
+// escrowFinishMeta is the binary metadata of EscrowFinish 1A76D4BA…3C3C, as
+// in the xrpl.js ripple-binary-codec fixtures. Its TransactionIndex is 11.
+const escrowFinishMeta = "201C0000000BF8E511006125020B814F55F72706F8C7B9C07D83332BE330D77B5CE6A246FE4FA04DD47EBC88719A35B5F95644A462A2806A513D7480CA71059D3D33637B65458017A8828A8F95AF17272501E6624000000010DC67D0E1E7220000000024000000052D00000000624000000013C074F08114DC0BCC71D87BB4E684B35721DC12F2C4E1ABABA4E1E1E4110075569766AE124E5053BCFDE253F6E3DDBAC13858CC0700DDECDCD57FF2FA777BEF7DE7220000000025020B814F202521A0C1B834000000000000000039000000000000000055F72706F8C7B9C07D83332BE330D77B5CE6A246FE4FA04DD47EBC88719A35B5F9614000000002E40D208114E151CA3207BAB5B91D2F0E4D35ECDFD4551C69A18314DC0BCC71D87BB4E684B35721DC12F2C4E1ABABA4E1E1E511006456BA4B47767C25E21CCDA3553BD45BE3699B34B508B459FE0472C70C51660058E0E7220000000058BA4B47767C25E21CCDA3553BD45BE3699B34B508B459FE0472C70C51660058E08214E151CA3207BAB5B91D2F0E4D35ECDFD4551C69A1E1E1E511006125020B814F55F72706F8C7B9C07D83332BE330D77B5CE6A246FE4FA04DD47EBC88719A35B5F956E4DA2A510F0C7FFD0474FAA4C7308A83828E0B3DD09EAA9CFDFFC067E3719D2EE624000000032D00000001624000000002FAF06CE1E7220000000024000000042D00000000624000000002FAF0628114E151CA3207BAB5B91D2F0E4D35ECDFD4551C69A1E1E1E511006456ECE79D27042E87B02DF3A263DB6BB6FCD96E69E20E0955F84D47D164C37546A1E7220000000058ECE79D27042E87B02DF3A263DB6BB6FCD96E69E20E0955F84D47D164C37546A18214DC0BCC71D87BB4E684B35721DC12F2C4E1ABABA4E1E1F1031000"
+
+func TestCTIDFromTxResult(t *testing.T) {
+	tests := []struct {
+		name    string
+		network Network
+		result  string
+		ctid    string
+	}{
+		{
+			// The XLS-37 example: ledger 1, transaction 2, network 3.
+			name:    "json",
+			network: 3,
+			result:  `{"validated": true, "ledger_index": 1, "meta": {"TransactionIndex": 2, "TransactionResult": "tesSUCCESS"}}`,
+			ctid:    "C000000100020003",
+		},
+		{
+			name:    "binary v1",
+			network: NetworkXrplMainnet,
+			result:  `{"validated": true, "ledger_index": 34308432, "tx": "12", "meta": "` + escrowFinishMeta + `"}`,
+			ctid:    "C20B8150000B0000",
+		},
+		{
+			name:    "binary v2",
+			network: NetworkXrplMainnet,
+			result:  `{"validated": true, "ledger_index": 34308432, "tx_blob": "12", "meta_blob": "` + escrowFinishMeta + `"}`,
+			ctid:    "C20B8150000B0000",
+		},
+		{
+			name:   "no metadata",
+			result: `{"validated": true, "ledger_index": 1, "hash": "1A76D4BA47A53A66B539D4BD4C30826A5E51C78D0B7344758EACAC77A6753C3C"}`,
+		},
+		{
+			name:   "no ledger index",
+			result: `{"validated": true, "meta": {"TransactionIndex": 2, "TransactionResult": "tesSUCCESS"}}`,
+		},
+		{
+			name:   "not validated",
+			result: `{"ledger_index": 1, "meta": {"TransactionIndex": 2, "TransactionResult": "tesSUCCESS"}}`,
+		},
+		{
+			name:   "bad metadata blob",
+			result: `{"validated": true, "ledger_index": 1, "meta_blob": "201C"}`,
+		},
+	}
+	for _, test := range tests {
+		var result methods.TxResponseResult
+		if err := json.Unmarshal([]byte(test.result), &result); err != nil {
+			t.Fatalf("%s: %v", test.name, err)
+		}
+		ctid, err := test.network.CTIDFromTxResult(result)
+		if test.ctid == "" {
+			if err == nil {
+				t.Errorf("%s: got CTID %s, want error", test.name, ctid.Encode())
+			}
+			continue
+		}
+		if err != nil {
+			t.Errorf("%s: %v", test.name, err)
+		} else if got := ctid.Encode(); got != test.ctid {
+			t.Errorf("%s: got %s, want %s", test.name, got, test.ctid)
+		}
+	}
+}
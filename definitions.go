@@ -0,0 +1,36 @@
+package xrpl
+
+import (
+	"errors"
+
+	"github.com/xrpscan/xrpl-go/binarycodec"
+	"github.com/xrpscan/xrpl-go/methods"
+)
+
+// ServerDefinitions retrieves the binary codec definitions of the connected
+// server. If hash is the hash of the server's current definitions, rippled
+// answers with the hash alone and ErrDefinitionsUnchanged is returned.
+func (c *Client) ServerDefinitions(hash string) (*binarycodec.Definitions, error) {
+	req := methods.ServerDefinitionsRequest{Hash: hash}
+	req.Command = "server_definitions"
+	var res methods.ServerDefinitionsResponse
+	if err := c.RequestInto(req, &res); err != nil {
+		return nil, err
+	}
+
+	values, err := scanFields(res.Result, "hash", "FIELDS")
+	if err != nil {
+		return nil, err
+	}
+	if values[1] == nil {
+		if hash != "" && rawString(values[0]) == hash {
+			return nil, ErrDefinitionsUnchanged
+		}
+		return nil, errors.New("server_definitions returned no definitions")
+	}
+	return binarycodec.LoadDefinitions(res.Result)
+}
+
+// ErrDefinitionsUnchanged is returned by ServerDefinitions when the server's
+// definitions match the hash passed in.
+var ErrDefinitionsUnchanged = errors.New("definitions unchanged")
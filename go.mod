@@ -2,6 +2,18 @@ module github.com/xrpscan/xrpl-go
 
 go 1.20
 
-require github.com/gorilla/websocket v1.5.1
+require (
+	github.com/decred/dcrd/dcrec/secp256k1/v4 v4.2.0
+	github.com/gorilla/websocket v1.5.1
+	github.com/tyler-smith/go-bip39 v1.1.0
+	golang.org/x/crypto v0.24.0
+	google.golang.org/grpc v1.64.1
+	google.golang.org/protobuf v1.33.0
+)
 
-require golang.org/x/net v0.17.0 // indirect
+require (
+	golang.org/x/net v0.26.0 // indirect
+	golang.org/x/sys v0.21.0 // indirect
+	golang.org/x/text v0.16.0 // indirect
+	google.golang.org/genproto/googleapis/rpc v0.0.0-20240318140521-94a12d6c2237 // indirect
+)
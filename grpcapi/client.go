@@ -0,0 +1,264 @@
+package grpcapi
+
+import (
+	"context"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/xrpscan/xrpl-go/binarycodec"
+	"github.com/xrpscan/xrpl-go/ledger"
+	"github.com/xrpscan/xrpl-go/models"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials/insecure"
+)
+
+const serviceName = "org.xrpl.rpc.v1.XRPLedgerAPIService"
+
+// Default limit for the size of a response. Full ledgers with state objects
+// are much larger than gRPC's default of 4 MiB.
+const MaxMessageSize = 256 << 20
+
+// Size of a serialized ledger header, with and without the trailing hash.
+const (
+	ledgerHeaderSize         = 118
+	ledgerHeaderSizeWithHash = ledgerHeaderSize + 32
+)
+
+// Client is a client for rippled's gRPC API, which serves ledgers, ledger
+// entries and transactions as binary blobs. It is much faster than the
+// websocket API for bulk history retrieval.
+type Client struct {
+	// Definitions decode the blobs returned by the server. NewClient sets
+	// them to binarycodec.DefaultDefinitions; set the definitions of the
+	// server's network for Xahau or sidechains.
+	Definitions *binarycodec.Definitions
+
+	conn *grpc.ClientConn
+}
+
+// NewClient creates a client for the gRPC port of a rippled server, set with
+// port_grpc in rippled.cfg, e.g. "localhost:50051". Without options the
+// connection is insecure.
+func NewClient(target string, opts ...grpc.DialOption) (*Client, error) {
+	if len(opts) == 0 {
+		opts = []grpc.DialOption{grpc.WithTransportCredentials(insecure.NewCredentials())}
+	}
+	opts = append(opts, grpc.WithDefaultCallOptions(
+		grpc.ForceCodec(codec{}),
+		grpc.MaxCallRecvMsgSize(MaxMessageSize),
+	))
+	conn, err := grpc.NewClient(target, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return &Client{Definitions: binarycodec.DefaultDefinitions(), conn: conn}, nil
+}
+
+func (c *Client) Close() error {
+	return c.conn.Close()
+}
+
+// GetLedger fetches a ledger header and, optionally, its transactions and
+// state changes.
+func (c *Client) GetLedger(ctx context.Context, req *GetLedgerRequest) (*GetLedgerResponse, error) {
+	res := &GetLedgerResponse{}
+	err := c.conn.Invoke(ctx, "/"+serviceName+"/GetLedger", req, res)
+	return res, err
+}
+
+// GetLedgerData fetches one page of the state tree of a ledger.
+func (c *Client) GetLedgerData(ctx context.Context, req *GetLedgerDataRequest) (*GetLedgerDataResponse, error) {
+	res := &GetLedgerDataResponse{}
+	err := c.conn.Invoke(ctx, "/"+serviceName+"/GetLedgerData", req, res)
+	return res, err
+}
+
+// GetLedgerEntry fetches a single ledger entry by key.
+func (c *Client) GetLedgerEntry(ctx context.Context, req *GetLedgerEntryRequest) (*GetLedgerEntryResponse, error) {
+	res := &GetLedgerEntryResponse{}
+	err := c.conn.Invoke(ctx, "/"+serviceName+"/GetLedgerEntry", req, res)
+	return res, err
+}
+
+// GetLedgerDiff fetches the ledger entries that differ between two ledgers.
+func (c *Client) GetLedgerDiff(ctx context.Context, req *GetLedgerDiffRequest) (*GetLedgerDiffResponse, error) {
+	res := &GetLedgerDiffResponse{}
+	err := c.conn.Invoke(ctx, "/"+serviceName+"/GetLedgerDiff", req, res)
+	return res, err
+}
+
+// Ledger is a ledger fetched over gRPC, decoded into the same models as the
+// websocket API. LedgerData keeps the serialized header, and each
+// transaction keeps its blobs, so that the ledger can be checked with the
+// ledger package.
+type Ledger struct {
+	ledger.Header
+	LedgerData        string // Serialized ledger header
+	Validated         bool
+	TransactionHashes []string
+	Transactions      []Transaction
+}
+
+// Transaction is a transaction of a ledger and its metadata.
+type Transaction struct {
+	models.BinaryTransaction
+	Hash        string
+	Transaction models.Transaction
+	Metadata    models.TransactionMetadata
+}
+
+// LedgerObject is a ledger entry, decoded into its JSON form.
+type LedgerObject struct {
+	models.BinaryLedgerObject
+	Entry map[string]interface{}
+}
+
+// BinaryTransactions returns the transaction and metadata blobs of the
+// ledger, as taken by ledger.Header.VerifyTransactions.
+func (l Ledger) BinaryTransactions() []models.BinaryTransaction {
+	txs := make([]models.BinaryTransaction, len(l.Transactions))
+	for i, tx := range l.Transactions {
+		txs[i] = tx.BinaryTransaction
+	}
+	return txs
+}
+
+// Ledger fetches a ledger. With transactions set, it includes transaction
+// hashes, or decoded transactions and metadata when expand is also set.
+func (c *Client) Ledger(ctx context.Context, ledgerSpec *LedgerSpecifier, transactions bool, expand bool) (Ledger, error) {
+	res, err := c.GetLedger(ctx, &GetLedgerRequest{
+		Ledger:       ledgerSpec,
+		Transactions: transactions,
+		Expand:       expand,
+	})
+	if err != nil {
+		return Ledger{}, err
+	}
+
+	header := res.LedgerHeader
+	if len(header) != ledgerHeaderSize && len(header) != ledgerHeaderSizeWithHash {
+		return Ledger{}, errors.New("grpcapi: unexpected ledger header size")
+	}
+	decoded, err := ledger.DecodeHeader(hexUpper(header))
+	if err != nil {
+		return Ledger{}, err
+	}
+	result := Ledger{
+		Header:     decoded,
+		LedgerData: hexUpper(header[:ledgerHeaderSize]),
+		Validated:  res.Validated,
+	}
+	for _, hash := range res.TransactionHashes {
+		result.TransactionHashes = append(result.TransactionHashes, hexUpper(hash))
+	}
+	for _, tx := range res.Transactions {
+		decoded, err := c.decodeTransaction(tx)
+		if err != nil {
+			return Ledger{}, err
+		}
+		result.Transactions = append(result.Transactions, decoded)
+	}
+	return result, nil
+}
+
+// LedgerData fetches one page of ledger entries. Pass the returned marker
+// to fetch the next page; an empty marker means there are no more.
+func (c *Client) LedgerData(ctx context.Context, ledgerSpec *LedgerSpecifier, marker string) ([]LedgerObject, string, error) {
+	req := &GetLedgerDataRequest{Ledger: ledgerSpec}
+	if len(marker) > 0 {
+		m, err := hex.DecodeString(marker)
+		if err != nil {
+			return nil, "", err
+		}
+		req.Marker = m
+	}
+	res, err := c.GetLedgerData(ctx, req)
+	if err != nil {
+		return nil, "", err
+	}
+
+	var objects []LedgerObject
+	if res.LedgerObjects != nil {
+		for _, o := range res.LedgerObjects.Objects {
+			object, err := c.decodeLedgerObject(o)
+			if err != nil {
+				return nil, "", err
+			}
+			objects = append(objects, object)
+		}
+	}
+	return objects, hexUpper(res.Marker), nil
+}
+
+// LedgerEntry fetches a single ledger entry by its hex-encoded key.
+func (c *Client) LedgerEntry(ctx context.Context, ledgerSpec *LedgerSpecifier, key string) (LedgerObject, error) {
+	k, err := hex.DecodeString(key)
+	if err != nil {
+		return LedgerObject{}, err
+	}
+	res, err := c.GetLedgerEntry(ctx, &GetLedgerEntryRequest{Key: k, Ledger: ledgerSpec})
+	if err != nil {
+		return LedgerObject{}, err
+	}
+	if res.LedgerObject == nil {
+		return LedgerObject{}, errors.New("grpcapi: ledger entry not found")
+	}
+	return c.decodeLedgerObject(res.LedgerObject)
+}
+
+func (c *Client) decodeTransaction(raw *TransactionAndMetadata) (Transaction, error) {
+	tx := Transaction{
+		BinaryTransaction: models.BinaryTransaction{
+			TxBlob: hexUpper(raw.TransactionBlob),
+			Meta:   hexUpper(raw.MetadataBlob),
+		},
+	}
+	hash, err := binarycodec.HashTransaction(tx.TxBlob)
+	if err != nil {
+		return tx, err
+	}
+	tx.Hash = hash
+
+	fields, err := c.Definitions.Decode(tx.TxBlob)
+	if err != nil {
+		return tx, fmt.Errorf("grpcapi: transaction %s: %w", hash, err)
+	}
+	data, err := json.Marshal(fields)
+	if err != nil {
+		return tx, err
+	}
+	if tx.Transaction, err = models.UnmarshalTransaction(data); err != nil {
+		return tx, fmt.Errorf("grpcapi: transaction %s: %w", hash, err)
+	}
+	if len(raw.MetadataBlob) > 0 {
+		if err := c.Definitions.Unmarshal(tx.Meta, &tx.Metadata); err != nil {
+			return tx, fmt.Errorf("grpcapi: metadata of %s: %w", hash, err)
+		}
+	}
+	return tx, nil
+}
+
+func (c *Client) decodeLedgerObject(o *RawLedgerObject) (LedgerObject, error) {
+	object := LedgerObject{
+		BinaryLedgerObject: models.BinaryLedgerObject{
+			Data:  hexUpper(o.Data),
+			Index: hexUpper(o.Key),
+		},
+	}
+	if len(o.Data) == 0 {
+		return object, nil
+	}
+	entry, err := c.Definitions.Decode(object.Data)
+	if err != nil {
+		return object, fmt.Errorf("grpcapi: ledger entry %s: %w", object.Index, err)
+	}
+	object.Entry = entry
+	return object, nil
+}
+
+func hexUpper(b []byte) string {
+	return strings.ToUpper(hex.EncodeToString(b))
+}
@@ -0,0 +1,256 @@
+package grpcapi
+
+import (
+	"bytes"
+	"context"
+	"encoding/hex"
+	"encoding/json"
+	"net"
+	"os"
+	"testing"
+
+	"github.com/xrpscan/xrpl-go/binarycodec"
+	"github.com/xrpscan/xrpl-go/ledger"
+	"github.com/xrpscan/xrpl-go/models"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/test/bufconn"
+)
+
+// fullLedger is mainnet ledger 38129 with its transactions and complete
+// state, from the xrpl.js ripple-binary-codec fixtures.
+type fullLedger struct {
+	ledger.Header
+	Transactions []map[string]interface{} `json:"transactions"`
+	AccountState []map[string]interface{} `json:"accountState"`
+}
+
+func loadFullLedger(t *testing.T) fullLedger {
+	t.Helper()
+	data, err := os.ReadFile("../testdata/ledger-full-38129.json")
+	if err != nil {
+		t.Fatal(err)
+	}
+	var l fullLedger
+	if err := json.Unmarshal(data, &l); err != nil {
+		t.Fatal(err)
+	}
+	// The embedded Header's UnmarshalJSON hides the other fields.
+	var rest struct {
+		Transactions []map[string]interface{} `json:"transactions"`
+		AccountState []map[string]interface{} `json:"accountState"`
+	}
+	if err := json.Unmarshal(data, &rest); err != nil {
+		t.Fatal(err)
+	}
+	l.Transactions, l.AccountState = rest.Transactions, rest.AccountState
+	return l
+}
+
+// response serializes the ledger as rippled's GetLedger does: the header
+// followed by its hash, and the transactions with their metadata.
+func (l fullLedger) response(t *testing.T) *GetLedgerResponse {
+	t.Helper()
+	header, err := l.Header.Encode()
+	if err != nil {
+		t.Fatal(err)
+	}
+	res := &GetLedgerResponse{LedgerHeader: append(header, mustHex(t, l.LedgerHash)...)}
+	for _, tx := range l.Transactions {
+		fields := make(map[string]interface{})
+		for k, v := range tx {
+			if k != "hash" && k != "metaData" {
+				fields[k] = v
+			}
+		}
+		res.Transactions = append(res.Transactions, &TransactionAndMetadata{
+			TransactionBlob: mustEncode(t, fields),
+			MetadataBlob:    mustEncode(t, tx["metaData"].(map[string]interface{})),
+		})
+		res.TransactionHashes = append(res.TransactionHashes, mustHex(t, tx["hash"].(string)))
+	}
+	return res
+}
+
+func (l fullLedger) state(t *testing.T) []*RawLedgerObject {
+	t.Helper()
+	state := make([]*RawLedgerObject, len(l.AccountState))
+	for i, entry := range l.AccountState {
+		state[i] = &RawLedgerObject{Key: mustHex(t, entry["index"].(string)), Data: mustEncode(t, entry)}
+	}
+	return state
+}
+
+func mustHex(t *testing.T, s string) []byte {
+	t.Helper()
+	b, err := hex.DecodeString(s)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return b
+}
+
+func mustEncode(t *testing.T, obj map[string]interface{}) []byte {
+	t.Helper()
+	encoded, err := binarycodec.Encode(obj)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return mustHex(t, encoded)
+}
+
+// dialStandIn serves s over an in-memory connection and returns a client
+// for it.
+func dialStandIn(t *testing.T, s *StandIn) *Client {
+	t.Helper()
+	listener := bufconn.Listen(1 << 20)
+	server := NewServer()
+	RegisterLedgerServer(server, s)
+	go server.Serve(listener)
+	t.Cleanup(server.Stop)
+
+	client, err := NewClient("passthrough:///bufconn",
+		grpc.WithContextDialer(func(ctx context.Context, _ string) (net.Conn, error) {
+			return listener.DialContext(ctx)
+		}),
+		grpc.WithTransportCredentials(insecure.NewCredentials()),
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { client.Close() })
+	return client
+}
+
+func TestClientEndToEnd(t *testing.T) {
+	ctx := context.Background()
+	full := loadFullLedger(t)
+	state := full.state(t)
+
+	// The next ledger is made up: the first entry is deleted, the second
+	// modified and a new entry created.
+	next := full.response(t)
+	next.LedgerHeader = append([]byte{}, next.LedgerHeader[:ledgerHeaderSize]...)
+	next.LedgerHeader[3]++
+	next.Transactions, next.TransactionHashes = nil, nil
+	nextState := append([]*RawLedgerObject{}, state[1:]...)
+	nextState[0] = &RawLedgerObject{Key: state[1].Key, Data: append(append([]byte{}, state[1].Data...), 0x11)}
+	created := &RawLedgerObject{Key: bytes.Repeat([]byte{0xFF}, 32), Data: state[2].Data}
+	nextState = append(nextState, created)
+
+	standIn := NewStandIn()
+	standIn.PageSize = 100
+	standIn.AddLedger(full.response(t), state)
+	standIn.AddLedger(next, nextState)
+	client := dialStandIn(t, standIn)
+
+	t.Run("Ledger", func(t *testing.T) {
+		l, err := client.Ledger(ctx, LedgerBySequence(38129), true, true)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if l.LedgerIndex != 38129 || l.LedgerHash != full.LedgerHash || !l.Validated {
+			t.Errorf("got ledger %d %s validated %v", l.LedgerIndex, l.LedgerHash, l.Validated)
+		}
+		if err := l.Header.Verify(); err != nil {
+			t.Error(err)
+		}
+		if err := l.Header.VerifyTransactions(l.BinaryTransactions()); err != nil {
+			t.Error(err)
+		}
+		if len(l.Transactions) != 1 {
+			t.Fatalf("got %d transactions", len(l.Transactions))
+		}
+		tx := l.Transactions[0]
+		if tx.Hash != full.Transactions[0]["hash"] {
+			t.Errorf("transaction hash %s", tx.Hash)
+		}
+		if payment, ok := tx.Transaction.(*models.TransactionPayment); !ok || payment.Destination != "rLQBHVhFnaC5gLEkgr6HgBJJ3bgeZHg9cj" {
+			t.Errorf("transaction decoded as %#v", tx.Transaction)
+		}
+		if tx.Metadata.TransactionResult != "tesSUCCESS" {
+			t.Errorf("metadata decoded as %#v", tx.Metadata)
+		}
+
+		l, err = client.Ledger(ctx, LedgerByHash(mustHex(t, full.LedgerHash)), true, false)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if len(l.TransactionHashes) != 1 || l.TransactionHashes[0] != full.Transactions[0]["hash"] || l.Transactions != nil {
+			t.Errorf("got hashes %v and transactions %v", l.TransactionHashes, l.Transactions)
+		}
+
+		if _, err := client.Ledger(ctx, LedgerBySequence(1), false, false); err == nil {
+			t.Error("got a ledger the stand-in does not have")
+		}
+	})
+
+	t.Run("LedgerData", func(t *testing.T) {
+		var objects []LedgerObject
+		pages := 0
+		for marker := ""; pages == 0 || marker != ""; pages++ {
+			page, next, err := client.LedgerData(ctx, LedgerBySequence(38129), marker)
+			if err != nil {
+				t.Fatal(err)
+			}
+			objects = append(objects, page...)
+			marker = next
+		}
+		if pages != 3 || len(objects) != len(state) {
+			t.Fatalf("got %d objects in %d pages, want %d in 3", len(objects), pages, len(state))
+		}
+		for i := 1; i < len(objects); i++ {
+			if objects[i-1].Index >= objects[i].Index {
+				t.Fatalf("objects out of order at %d", i)
+			}
+		}
+		for _, o := range objects {
+			if o.Entry["LedgerEntryType"] == nil || o.Entry["index"] != nil {
+				t.Fatalf("entry %s decoded as %v", o.Index, o.Entry)
+			}
+		}
+
+		res, err := client.GetLedgerData(ctx, &GetLedgerDataRequest{Ledger: LedgerBySequence(38129)})
+		if err != nil {
+			t.Fatal(err)
+		}
+		if res.LedgerIndex != 38129 || hexUpper(res.LedgerHash) != full.LedgerHash {
+			t.Errorf("got ledger %d %X", res.LedgerIndex, res.LedgerHash)
+		}
+	})
+
+	t.Run("LedgerEntry", func(t *testing.T) {
+		want := full.AccountState[0]
+		o, err := client.LedgerEntry(ctx, LedgerBySequence(38129), want["index"].(string))
+		if err != nil {
+			t.Fatal(err)
+		}
+		if o.Index != want["index"] || o.Entry["Account"] != want["Account"] || o.Entry["Balance"] != want["Balance"] {
+			t.Errorf("got %s %v", o.Index, o.Entry)
+		}
+		if _, err := client.LedgerEntry(ctx, nil, want["index"].(string)); err == nil {
+			t.Error("got an entry deleted from the validated ledger")
+		}
+	})
+
+	t.Run("GetLedgerDiff", func(t *testing.T) {
+		res, err := client.GetLedgerDiff(ctx, &GetLedgerDiffRequest{
+			BaseLedger:    LedgerBySequence(38129),
+			DesiredLedger: ValidatedLedger(),
+			IncludeBlobs:  true,
+		})
+		if err != nil {
+			t.Fatal(err)
+		}
+		changes := make(map[ModificationType][]*RawLedgerObject)
+		for _, o := range res.LedgerObjects.Objects {
+			changes[o.ModType] = append(changes[o.ModType], o)
+		}
+		if len(res.LedgerObjects.Objects) != 3 ||
+			!bytes.Equal(changes[ModificationDeleted][0].Key, state[0].Key) ||
+			!bytes.Equal(changes[ModificationModified][0].Data, nextState[0].Data) ||
+			!bytes.Equal(changes[ModificationCreated][0].Key, created.Key) {
+			t.Errorf("got diff %v", changes)
+		}
+	})
+}
@@ -0,0 +1,30 @@
+package grpcapi
+
+import "fmt"
+
+// codec moves this package's hand-encoded messages on and off the wire. It
+// is not registered with grpc's encoding registry; NewClient and NewServer
+// apply it with ForceCodec and ForceServerCodec. It reports the name
+// "proto", the content subtype generated protobuf code uses, so it
+// interoperates with servers and clients built from rippled's .proto files.
+type codec struct{}
+
+func (codec) Marshal(v interface{}) ([]byte, error) {
+	m, ok := v.(message)
+	if !ok {
+		return nil, fmt.Errorf("grpcapi: cannot marshal %T", v)
+	}
+	return m.marshal(), nil
+}
+
+func (codec) Unmarshal(data []byte, v interface{}) error {
+	m, ok := v.(message)
+	if !ok {
+		return fmt.Errorf("grpcapi: cannot unmarshal into %T", v)
+	}
+	return m.unmarshal(data)
+}
+
+func (codec) Name() string {
+	return "proto"
+}
@@ -0,0 +1,681 @@
+package grpcapi
+
+import (
+	"errors"
+
+	"google.golang.org/protobuf/encoding/protowire"
+)
+
+// Messages of rippled's org.xrpl.rpc.v1 gRPC API, encoded by hand with
+// protowire. Field numbers follow the .proto files in rippled:
+// https://github.com/XRPLF/rippled/tree/develop/src/xrpld/proto/org/xrpl/rpc/v1
+
+// message is implemented by every request and response type so that the
+// codec can move them on and off the wire.
+type message interface {
+	marshal() []byte
+	unmarshal(b []byte) error
+}
+
+var errInvalidWireData = errors.New("invalid protobuf wire data")
+
+type LedgerShortcut int32
+
+const (
+	ShortcutUnspecified LedgerShortcut = 0
+	ShortcutValidated   LedgerShortcut = 1
+	ShortcutClosed      LedgerShortcut = 2
+	ShortcutCurrent     LedgerShortcut = 3
+)
+
+// LedgerSpecifier selects a ledger by shortcut, sequence or hash. Only one of
+// the three may be set.
+type LedgerSpecifier struct {
+	Shortcut LedgerShortcut
+	Sequence uint32
+	Hash     []byte
+}
+
+// ValidatedLedger selects the most recent validated ledger.
+func ValidatedLedger() *LedgerSpecifier {
+	return &LedgerSpecifier{Shortcut: ShortcutValidated}
+}
+
+// LedgerBySequence selects a ledger by its sequence number.
+func LedgerBySequence(sequence uint32) *LedgerSpecifier {
+	return &LedgerSpecifier{Sequence: sequence}
+}
+
+// LedgerByHash selects a ledger by its hash.
+func LedgerByHash(hash []byte) *LedgerSpecifier {
+	return &LedgerSpecifier{Hash: hash}
+}
+
+func (m *LedgerSpecifier) marshal() []byte {
+	var b []byte
+	switch {
+	case len(m.Hash) > 0:
+		b = protowire.AppendTag(b, 3, protowire.BytesType)
+		b = protowire.AppendBytes(b, m.Hash)
+	case m.Sequence != 0:
+		b = protowire.AppendTag(b, 2, protowire.VarintType)
+		b = protowire.AppendVarint(b, uint64(m.Sequence))
+	case m.Shortcut != ShortcutUnspecified:
+		b = protowire.AppendTag(b, 1, protowire.VarintType)
+		b = protowire.AppendVarint(b, uint64(m.Shortcut))
+	}
+	return b
+}
+
+func (m *LedgerSpecifier) unmarshal(b []byte) error {
+	*m = LedgerSpecifier{}
+	return consumeFields(b, func(num protowire.Number, typ protowire.Type, b []byte) (int, error) {
+		switch {
+		case num == 1 && typ == protowire.VarintType:
+			v, n := protowire.ConsumeVarint(b)
+			m.Shortcut = LedgerShortcut(v)
+			return n, nil
+		case num == 2 && typ == protowire.VarintType:
+			v, n := protowire.ConsumeVarint(b)
+			m.Sequence = uint32(v)
+			return n, nil
+		case num == 3 && typ == protowire.BytesType:
+			v, n := protowire.ConsumeBytes(b)
+			m.Hash = clone(v)
+			return n, nil
+		}
+		return skipField, nil
+	})
+}
+
+type ModificationType int32
+
+const (
+	ModificationUnspecified ModificationType = 0
+	ModificationCreated     ModificationType = 1
+	ModificationModified    ModificationType = 2
+	ModificationDeleted     ModificationType = 3
+)
+
+// RawLedgerObject is a serialized ledger entry and its key.
+type RawLedgerObject struct {
+	Data        []byte
+	Key         []byte
+	ModType     ModificationType
+	Predecessor []byte
+	Successor   []byte
+}
+
+func (m *RawLedgerObject) marshal() []byte {
+	var b []byte
+	b = appendBytes(b, 1, m.Data)
+	b = appendBytes(b, 2, m.Key)
+	b = appendVarint(b, 3, uint64(m.ModType))
+	b = appendBytes(b, 4, m.Predecessor)
+	b = appendBytes(b, 5, m.Successor)
+	return b
+}
+
+func (m *RawLedgerObject) unmarshal(b []byte) error {
+	*m = RawLedgerObject{}
+	return consumeFields(b, func(num protowire.Number, typ protowire.Type, b []byte) (int, error) {
+		switch {
+		case num == 1 && typ == protowire.BytesType:
+			return consumeBytes(b, &m.Data)
+		case num == 2 && typ == protowire.BytesType:
+			return consumeBytes(b, &m.Key)
+		case num == 3 && typ == protowire.VarintType:
+			v, n := protowire.ConsumeVarint(b)
+			m.ModType = ModificationType(v)
+			return n, nil
+		case num == 4 && typ == protowire.BytesType:
+			return consumeBytes(b, &m.Predecessor)
+		case num == 5 && typ == protowire.BytesType:
+			return consumeBytes(b, &m.Successor)
+		}
+		return skipField, nil
+	})
+}
+
+type RawLedgerObjects struct {
+	Objects []*RawLedgerObject
+}
+
+func (m *RawLedgerObjects) marshal() []byte {
+	var b []byte
+	for _, o := range m.Objects {
+		b = appendMessage(b, 1, o)
+	}
+	return b
+}
+
+func (m *RawLedgerObjects) unmarshal(b []byte) error {
+	*m = RawLedgerObjects{}
+	return consumeFields(b, func(num protowire.Number, typ protowire.Type, b []byte) (int, error) {
+		if num == 1 && typ == protowire.BytesType {
+			o := &RawLedgerObject{}
+			m.Objects = append(m.Objects, o)
+			return consumeMessage(b, o)
+		}
+		return skipField, nil
+	})
+}
+
+// TransactionAndMetadata is a serialized transaction and its metadata.
+type TransactionAndMetadata struct {
+	TransactionBlob []byte
+	MetadataBlob    []byte
+}
+
+func (m *TransactionAndMetadata) marshal() []byte {
+	var b []byte
+	b = appendBytes(b, 1, m.TransactionBlob)
+	b = appendBytes(b, 2, m.MetadataBlob)
+	return b
+}
+
+func (m *TransactionAndMetadata) unmarshal(b []byte) error {
+	*m = TransactionAndMetadata{}
+	return consumeFields(b, func(num protowire.Number, typ protowire.Type, b []byte) (int, error) {
+		switch {
+		case num == 1 && typ == protowire.BytesType:
+			return consumeBytes(b, &m.TransactionBlob)
+		case num == 2 && typ == protowire.BytesType:
+			return consumeBytes(b, &m.MetadataBlob)
+		}
+		return skipField, nil
+	})
+}
+
+type GetLedgerRequest struct {
+	Ledger             *LedgerSpecifier
+	Transactions       bool
+	Expand             bool
+	GetObjects         bool
+	ClientIp           string
+	User               string
+	GetObjectNeighbors bool
+}
+
+func (m *GetLedgerRequest) marshal() []byte {
+	var b []byte
+	if m.Ledger != nil {
+		b = appendMessage(b, 1, m.Ledger)
+	}
+	b = appendBool(b, 2, m.Transactions)
+	b = appendBool(b, 3, m.Expand)
+	b = appendBool(b, 4, m.GetObjects)
+	b = appendString(b, 5, m.ClientIp)
+	b = appendString(b, 6, m.User)
+	b = appendBool(b, 7, m.GetObjectNeighbors)
+	return b
+}
+
+func (m *GetLedgerRequest) unmarshal(b []byte) error {
+	*m = GetLedgerRequest{}
+	return consumeFields(b, func(num protowire.Number, typ protowire.Type, b []byte) (int, error) {
+		switch {
+		case num == 1 && typ == protowire.BytesType:
+			m.Ledger = &LedgerSpecifier{}
+			return consumeMessage(b, m.Ledger)
+		case num == 2 && typ == protowire.VarintType:
+			return consumeBool(b, &m.Transactions)
+		case num == 3 && typ == protowire.VarintType:
+			return consumeBool(b, &m.Expand)
+		case num == 4 && typ == protowire.VarintType:
+			return consumeBool(b, &m.GetObjects)
+		case num == 5 && typ == protowire.BytesType:
+			return consumeString(b, &m.ClientIp)
+		case num == 6 && typ == protowire.BytesType:
+			return consumeString(b, &m.User)
+		case num == 7 && typ == protowire.VarintType:
+			return consumeBool(b, &m.GetObjectNeighbors)
+		}
+		return skipField, nil
+	})
+}
+
+// BookSuccessor is the first quality of an order book that changed in a
+// ledger, returned when GetLedgerRequest.GetObjectNeighbors is set.
+type BookSuccessor struct {
+	BookBase      []byte
+	BookSuccessor []byte
+}
+
+func (m *BookSuccessor) marshal() []byte {
+	var b []byte
+	b = appendBytes(b, 1, m.BookBase)
+	b = appendBytes(b, 2, m.BookSuccessor)
+	return b
+}
+
+func (m *BookSuccessor) unmarshal(b []byte) error {
+	*m = BookSuccessor{}
+	return consumeFields(b, func(num protowire.Number, typ protowire.Type, b []byte) (int, error) {
+		switch {
+		case num == 1 && typ == protowire.BytesType:
+			return consumeBytes(b, &m.BookBase)
+		case num == 2 && typ == protowire.BytesType:
+			return consumeBytes(b, &m.BookSuccessor)
+		}
+		return skipField, nil
+	})
+}
+
+// GetLedgerResponse carries either TransactionHashes or Transactions,
+// depending on whether the request asked for expanded transactions.
+type GetLedgerResponse struct {
+	LedgerHeader            []byte
+	TransactionHashes       [][]byte
+	Transactions            []*TransactionAndMetadata
+	Validated               bool
+	LedgerObjects           *RawLedgerObjects
+	SkiplistIncluded        bool
+	IsUnlimited             bool
+	ObjectsIncluded         bool
+	ObjectNeighborsIncluded bool
+	BookSuccessors          []*BookSuccessor
+}
+
+func (m *GetLedgerResponse) marshal() []byte {
+	var b []byte
+	b = appendBytes(b, 1, m.LedgerHeader)
+	if m.TransactionHashes != nil {
+		var list []byte
+		for _, h := range m.TransactionHashes {
+			list = protowire.AppendTag(list, 1, protowire.BytesType)
+			list = protowire.AppendBytes(list, h)
+		}
+		b = protowire.AppendTag(b, 2, protowire.BytesType)
+		b = protowire.AppendBytes(b, list)
+	} else if m.Transactions != nil {
+		var list []byte
+		for _, t := range m.Transactions {
+			list = appendMessage(list, 1, t)
+		}
+		b = protowire.AppendTag(b, 3, protowire.BytesType)
+		b = protowire.AppendBytes(b, list)
+	}
+	b = appendBool(b, 4, m.Validated)
+	if m.LedgerObjects != nil {
+		b = appendMessage(b, 5, m.LedgerObjects)
+	}
+	b = appendBool(b, 6, m.SkiplistIncluded)
+	b = appendBool(b, 7, m.IsUnlimited)
+	b = appendBool(b, 8, m.ObjectsIncluded)
+	b = appendBool(b, 9, m.ObjectNeighborsIncluded)
+	for _, s := range m.BookSuccessors {
+		b = appendMessage(b, 10, s)
+	}
+	return b
+}
+
+func (m *GetLedgerResponse) unmarshal(b []byte) error {
+	*m = GetLedgerResponse{}
+	return consumeFields(b, func(num protowire.Number, typ protowire.Type, b []byte) (int, error) {
+		switch {
+		case num == 1 && typ == protowire.BytesType:
+			return consumeBytes(b, &m.LedgerHeader)
+		case num == 2 && typ == protowire.BytesType:
+			list, n := protowire.ConsumeBytes(b)
+			if n < 0 {
+				return n, nil
+			}
+			m.TransactionHashes = [][]byte{}
+			return n, consumeFields(list, func(num protowire.Number, typ protowire.Type, b []byte) (int, error) {
+				if num == 1 && typ == protowire.BytesType {
+					v, n := protowire.ConsumeBytes(b)
+					m.TransactionHashes = append(m.TransactionHashes, clone(v))
+					return n, nil
+				}
+				return skipField, nil
+			})
+		case num == 3 && typ == protowire.BytesType:
+			list, n := protowire.ConsumeBytes(b)
+			if n < 0 {
+				return n, nil
+			}
+			m.Transactions = []*TransactionAndMetadata{}
+			return n, consumeFields(list, func(num protowire.Number, typ protowire.Type, b []byte) (int, error) {
+				if num == 1 && typ == protowire.BytesType {
+					t := &TransactionAndMetadata{}
+					m.Transactions = append(m.Transactions, t)
+					return consumeMessage(b, t)
+				}
+				return skipField, nil
+			})
+		case num == 4 && typ == protowire.VarintType:
+			return consumeBool(b, &m.Validated)
+		case num == 5 && typ == protowire.BytesType:
+			m.LedgerObjects = &RawLedgerObjects{}
+			return consumeMessage(b, m.LedgerObjects)
+		case num == 6 && typ == protowire.VarintType:
+			return consumeBool(b, &m.SkiplistIncluded)
+		case num == 7 && typ == protowire.VarintType:
+			return consumeBool(b, &m.IsUnlimited)
+		case num == 8 && typ == protowire.VarintType:
+			return consumeBool(b, &m.ObjectsIncluded)
+		case num == 9 && typ == protowire.VarintType:
+			return consumeBool(b, &m.ObjectNeighborsIncluded)
+		case num == 10 && typ == protowire.BytesType:
+			s := &BookSuccessor{}
+			m.BookSuccessors = append(m.BookSuccessors, s)
+			return consumeMessage(b, s)
+		}
+		return skipField, nil
+	})
+}
+
+type GetLedgerDataRequest struct {
+	Ledger    *LedgerSpecifier
+	Marker    []byte
+	EndMarker []byte
+	ClientIp  string
+	User      string
+}
+
+func (m *GetLedgerDataRequest) marshal() []byte {
+	var b []byte
+	if m.Ledger != nil {
+		b = appendMessage(b, 1, m.Ledger)
+	}
+	b = appendBytes(b, 2, m.Marker)
+	b = appendBytes(b, 3, m.EndMarker)
+	b = appendString(b, 4, m.ClientIp)
+	b = appendString(b, 6, m.User)
+	return b
+}
+
+func (m *GetLedgerDataRequest) unmarshal(b []byte) error {
+	*m = GetLedgerDataRequest{}
+	return consumeFields(b, func(num protowire.Number, typ protowire.Type, b []byte) (int, error) {
+		switch {
+		case num == 1 && typ == protowire.BytesType:
+			m.Ledger = &LedgerSpecifier{}
+			return consumeMessage(b, m.Ledger)
+		case num == 2 && typ == protowire.BytesType:
+			return consumeBytes(b, &m.Marker)
+		case num == 3 && typ == protowire.BytesType:
+			return consumeBytes(b, &m.EndMarker)
+		case num == 4 && typ == protowire.BytesType:
+			return consumeString(b, &m.ClientIp)
+		case num == 6 && typ == protowire.BytesType:
+			return consumeString(b, &m.User)
+		}
+		return skipField, nil
+	})
+}
+
+// GetLedgerDataResponse carries one page of ledger entries. A non-empty
+// Marker means there are more pages.
+type GetLedgerDataResponse struct {
+	LedgerIndex   uint32
+	LedgerHash    []byte
+	LedgerObjects *RawLedgerObjects
+	Marker        []byte
+	IsUnlimited   bool
+}
+
+func (m *GetLedgerDataResponse) marshal() []byte {
+	var b []byte
+	b = appendVarint(b, 1, uint64(m.LedgerIndex))
+	b = appendBytes(b, 2, m.LedgerHash)
+	if m.LedgerObjects != nil {
+		b = appendMessage(b, 3, m.LedgerObjects)
+	}
+	b = appendBytes(b, 4, m.Marker)
+	b = appendBool(b, 5, m.IsUnlimited)
+	return b
+}
+
+func (m *GetLedgerDataResponse) unmarshal(b []byte) error {
+	*m = GetLedgerDataResponse{}
+	return consumeFields(b, func(num protowire.Number, typ protowire.Type, b []byte) (int, error) {
+		switch {
+		case num == 1 && typ == protowire.VarintType:
+			v, n := protowire.ConsumeVarint(b)
+			m.LedgerIndex = uint32(v)
+			return n, nil
+		case num == 2 && typ == protowire.BytesType:
+			return consumeBytes(b, &m.LedgerHash)
+		case num == 3 && typ == protowire.BytesType:
+			m.LedgerObjects = &RawLedgerObjects{}
+			return consumeMessage(b, m.LedgerObjects)
+		case num == 4 && typ == protowire.BytesType:
+			return consumeBytes(b, &m.Marker)
+		case num == 5 && typ == protowire.VarintType:
+			return consumeBool(b, &m.IsUnlimited)
+		}
+		return skipField, nil
+	})
+}
+
+type GetLedgerEntryRequest struct {
+	Key      []byte
+	Ledger   *LedgerSpecifier
+	ClientIp string
+	User     string
+}
+
+func (m *GetLedgerEntryRequest) marshal() []byte {
+	var b []byte
+	b = appendBytes(b, 1, m.Key)
+	if m.Ledger != nil {
+		b = appendMessage(b, 2, m.Ledger)
+	}
+	b = appendString(b, 3, m.ClientIp)
+	b = appendString(b, 4, m.User)
+	return b
+}
+
+func (m *GetLedgerEntryRequest) unmarshal(b []byte) error {
+	*m = GetLedgerEntryRequest{}
+	return consumeFields(b, func(num protowire.Number, typ protowire.Type, b []byte) (int, error) {
+		switch {
+		case num == 1 && typ == protowire.BytesType:
+			return consumeBytes(b, &m.Key)
+		case num == 2 && typ == protowire.BytesType:
+			m.Ledger = &LedgerSpecifier{}
+			return consumeMessage(b, m.Ledger)
+		case num == 3 && typ == protowire.BytesType:
+			return consumeString(b, &m.ClientIp)
+		case num == 4 && typ == protowire.BytesType:
+			return consumeString(b, &m.User)
+		}
+		return skipField, nil
+	})
+}
+
+type GetLedgerEntryResponse struct {
+	LedgerObject *RawLedgerObject
+	Ledger       *LedgerSpecifier
+}
+
+func (m *GetLedgerEntryResponse) marshal() []byte {
+	var b []byte
+	if m.LedgerObject != nil {
+		b = appendMessage(b, 1, m.LedgerObject)
+	}
+	if m.Ledger != nil {
+		b = appendMessage(b, 2, m.Ledger)
+	}
+	return b
+}
+
+func (m *GetLedgerEntryResponse) unmarshal(b []byte) error {
+	*m = GetLedgerEntryResponse{}
+	return consumeFields(b, func(num protowire.Number, typ protowire.Type, b []byte) (int, error) {
+		switch {
+		case num == 1 && typ == protowire.BytesType:
+			m.LedgerObject = &RawLedgerObject{}
+			return consumeMessage(b, m.LedgerObject)
+		case num == 2 && typ == protowire.BytesType:
+			m.Ledger = &LedgerSpecifier{}
+			return consumeMessage(b, m.Ledger)
+		}
+		return skipField, nil
+	})
+}
+
+type GetLedgerDiffRequest struct {
+	BaseLedger    *LedgerSpecifier
+	DesiredLedger *LedgerSpecifier
+	IncludeBlobs  bool
+	ClientIp      string
+}
+
+func (m *GetLedgerDiffRequest) marshal() []byte {
+	var b []byte
+	if m.BaseLedger != nil {
+		b = appendMessage(b, 1, m.BaseLedger)
+	}
+	if m.DesiredLedger != nil {
+		b = appendMessage(b, 2, m.DesiredLedger)
+	}
+	b = appendBool(b, 3, m.IncludeBlobs)
+	b = appendString(b, 4, m.ClientIp)
+	return b
+}
+
+func (m *GetLedgerDiffRequest) unmarshal(b []byte) error {
+	*m = GetLedgerDiffRequest{}
+	return consumeFields(b, func(num protowire.Number, typ protowire.Type, b []byte) (int, error) {
+		switch {
+		case num == 1 && typ == protowire.BytesType:
+			m.BaseLedger = &LedgerSpecifier{}
+			return consumeMessage(b, m.BaseLedger)
+		case num == 2 && typ == protowire.BytesType:
+			m.DesiredLedger = &LedgerSpecifier{}
+			return consumeMessage(b, m.DesiredLedger)
+		case num == 3 && typ == protowire.VarintType:
+			return consumeBool(b, &m.IncludeBlobs)
+		case num == 4 && typ == protowire.BytesType:
+			return consumeString(b, &m.ClientIp)
+		}
+		return skipField, nil
+	})
+}
+
+type GetLedgerDiffResponse struct {
+	LedgerObjects *RawLedgerObjects
+}
+
+func (m *GetLedgerDiffResponse) marshal() []byte {
+	var b []byte
+	if m.LedgerObjects != nil {
+		b = appendMessage(b, 1, m.LedgerObjects)
+	}
+	return b
+}
+
+func (m *GetLedgerDiffResponse) unmarshal(b []byte) error {
+	*m = GetLedgerDiffResponse{}
+	return consumeFields(b, func(num protowire.Number, typ protowire.Type, b []byte) (int, error) {
+		if num == 1 && typ == protowire.BytesType {
+			m.LedgerObjects = &RawLedgerObjects{}
+			return consumeMessage(b, m.LedgerObjects)
+		}
+		return skipField, nil
+	})
+}
+
+/*
+ * Wire helpers. Zero values are omitted, as in proto3.
+ */
+
+func appendVarint(b []byte, num protowire.Number, v uint64) []byte {
+	if v == 0 {
+		return b
+	}
+	b = protowire.AppendTag(b, num, protowire.VarintType)
+	return protowire.AppendVarint(b, v)
+}
+
+func appendBool(b []byte, num protowire.Number, v bool) []byte {
+	if !v {
+		return b
+	}
+	return appendVarint(b, num, 1)
+}
+
+func appendBytes(b []byte, num protowire.Number, v []byte) []byte {
+	if len(v) == 0 {
+		return b
+	}
+	b = protowire.AppendTag(b, num, protowire.BytesType)
+	return protowire.AppendBytes(b, v)
+}
+
+func appendString(b []byte, num protowire.Number, v string) []byte {
+	if len(v) == 0 {
+		return b
+	}
+	b = protowire.AppendTag(b, num, protowire.BytesType)
+	return protowire.AppendString(b, v)
+}
+
+func appendMessage(b []byte, num protowire.Number, m message) []byte {
+	b = protowire.AppendTag(b, num, protowire.BytesType)
+	return protowire.AppendBytes(b, m.marshal())
+}
+
+// skipField is returned by field parsers for fields they do not know. It is
+// distinct from protowire's negative error codes.
+const skipField = -1 << 20
+
+// consumeFields calls field for every field in b. field returns the number
+// of bytes of the value it consumed, or skipField to skip over the value.
+func consumeFields(b []byte, field func(protowire.Number, protowire.Type, []byte) (int, error)) error {
+	for len(b) > 0 {
+		num, typ, n := protowire.ConsumeTag(b)
+		if n < 0 {
+			return errInvalidWireData
+		}
+		b = b[n:]
+		n, err := field(num, typ, b)
+		if err != nil {
+			return err
+		}
+		if n == skipField {
+			n = protowire.ConsumeFieldValue(num, typ, b)
+		}
+		if n < 0 {
+			return errInvalidWireData
+		}
+		b = b[n:]
+	}
+	return nil
+}
+
+func consumeBytes(b []byte, v *[]byte) (int, error) {
+	value, n := protowire.ConsumeBytes(b)
+	*v = clone(value)
+	return n, nil
+}
+
+func consumeString(b []byte, v *string) (int, error) {
+	value, n := protowire.ConsumeString(b)
+	*v = value
+	return n, nil
+}
+
+func consumeBool(b []byte, v *bool) (int, error) {
+	value, n := protowire.ConsumeVarint(b)
+	*v = value != 0
+	return n, nil
+}
+
+func consumeMessage(b []byte, m message) (int, error) {
+	value, n := protowire.ConsumeBytes(b)
+	if n < 0 {
+		return n, nil
+	}
+	return n, m.unmarshal(value)
+}
+
+func clone(b []byte) []byte {
+	if b == nil {
+		return nil
+	}
+	return append([]byte{}, b...)
+}
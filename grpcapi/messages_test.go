@@ -0,0 +1,308 @@
+package grpcapi
+
+import (
+	"bytes"
+	"reflect"
+	"strings"
+	"testing"
+
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protodesc"
+	"google.golang.org/protobuf/reflect/protoreflect"
+	"google.golang.org/protobuf/types/descriptorpb"
+	"google.golang.org/protobuf/types/dynamicpb"
+)
+
+// rippledFile transcribes the messages of rippled's org/xrpl/rpc/v1 .proto
+// files (ledger.proto, get_ledger.proto, get_ledger_data.proto,
+// get_ledger_entry.proto and get_ledger_diff.proto) into a descriptor, so
+// that protobuf-go can check the hand-written encoding against them.
+func rippledFile(t *testing.T) protoreflect.FileDescriptor {
+	t.Helper()
+	field := func(name string, num int32, typ descriptorpb.FieldDescriptorProto_Type, typeName string) *descriptorpb.FieldDescriptorProto {
+		f := &descriptorpb.FieldDescriptorProto{
+			Name:     proto.String(name),
+			JsonName: proto.String(name),
+			Number:   proto.Int32(num),
+			Label:    descriptorpb.FieldDescriptorProto_LABEL_OPTIONAL.Enum(),
+			Type:     typ.Enum(),
+		}
+		if typeName != "" {
+			f.TypeName = proto.String(".org.xrpl.rpc.v1." + typeName)
+		}
+		return f
+	}
+	repeated := func(f *descriptorpb.FieldDescriptorProto) *descriptorpb.FieldDescriptorProto {
+		f.Label = descriptorpb.FieldDescriptorProto_LABEL_REPEATED.Enum()
+		return f
+	}
+	oneof := func(f *descriptorpb.FieldDescriptorProto) *descriptorpb.FieldDescriptorProto {
+		f.OneofIndex = proto.Int32(0)
+		return f
+	}
+	message := func(name string, fields ...*descriptorpb.FieldDescriptorProto) *descriptorpb.DescriptorProto {
+		return &descriptorpb.DescriptorProto{Name: proto.String(name), Field: fields}
+	}
+	enum := func(name string, values ...string) *descriptorpb.EnumDescriptorProto {
+		e := &descriptorpb.EnumDescriptorProto{Name: proto.String(name)}
+		for i, v := range values {
+			e.Value = append(e.Value, &descriptorpb.EnumValueDescriptorProto{Name: proto.String(v), Number: proto.Int32(int32(i))})
+		}
+		return e
+	}
+	const (
+		bytesType  = descriptorpb.FieldDescriptorProto_TYPE_BYTES
+		stringType = descriptorpb.FieldDescriptorProto_TYPE_STRING
+		boolType   = descriptorpb.FieldDescriptorProto_TYPE_BOOL
+		uint32Type = descriptorpb.FieldDescriptorProto_TYPE_UINT32
+		enumType   = descriptorpb.FieldDescriptorProto_TYPE_ENUM
+		msgType    = descriptorpb.FieldDescriptorProto_TYPE_MESSAGE
+	)
+
+	ledgerSpecifier := message("LedgerSpecifier",
+		oneof(field("shortcut", 1, enumType, "LedgerSpecifier.Shortcut")),
+		oneof(field("sequence", 2, uint32Type, "")),
+		oneof(field("hash", 3, bytesType, "")),
+	)
+	ledgerSpecifier.EnumType = []*descriptorpb.EnumDescriptorProto{
+		enum("Shortcut", "SHORTCUT_UNSPECIFIED", "SHORTCUT_VALIDATED", "SHORTCUT_CLOSED", "SHORTCUT_CURRENT"),
+	}
+	ledgerSpecifier.OneofDecl = []*descriptorpb.OneofDescriptorProto{{Name: proto.String("ledger")}}
+
+	rawLedgerObject := message("RawLedgerObject",
+		field("data", 1, bytesType, ""),
+		field("key", 2, bytesType, ""),
+		field("mod_type", 3, enumType, "RawLedgerObject.ModificationType"),
+		field("predecessor", 4, bytesType, ""),
+		field("successor", 5, bytesType, ""),
+	)
+	rawLedgerObject.EnumType = []*descriptorpb.EnumDescriptorProto{
+		enum("ModificationType", "UNSPECIFIED", "CREATED", "MODIFIED", "DELETED"),
+	}
+
+	getLedgerResponse := message("GetLedgerResponse",
+		field("ledger_header", 1, bytesType, ""),
+		oneof(field("hashes_list", 2, msgType, "TransactionHashList")),
+		oneof(field("transactions_list", 3, msgType, "TransactionAndMetadataList")),
+		field("validated", 4, boolType, ""),
+		field("ledger_objects", 5, msgType, "RawLedgerObjects"),
+		field("skiplist_included", 6, boolType, ""),
+		field("is_unlimited", 7, boolType, ""),
+		field("objects_included", 8, boolType, ""),
+		field("object_neighbors_included", 9, boolType, ""),
+		repeated(field("book_successors", 10, msgType, "BookSuccessor")),
+	)
+	getLedgerResponse.OneofDecl = []*descriptorpb.OneofDescriptorProto{{Name: proto.String("transactions")}}
+
+	file := &descriptorpb.FileDescriptorProto{
+		Name:    proto.String("org/xrpl/rpc/v1/xrp_ledger.proto"),
+		Package: proto.String("org.xrpl.rpc.v1"),
+		Syntax:  proto.String("proto3"),
+		MessageType: []*descriptorpb.DescriptorProto{
+			ledgerSpecifier,
+			rawLedgerObject,
+			message("RawLedgerObjects", repeated(field("objects", 1, msgType, "RawLedgerObject"))),
+			message("TransactionHashList", repeated(field("hashes", 1, bytesType, ""))),
+			message("TransactionAndMetadata",
+				field("transaction_blob", 1, bytesType, ""),
+				field("metadata_blob", 2, bytesType, ""),
+			),
+			message("TransactionAndMetadataList", repeated(field("transactions", 1, msgType, "TransactionAndMetadata"))),
+			message("BookSuccessor",
+				field("book_base", 1, bytesType, ""),
+				field("book_successor", 2, bytesType, ""),
+			),
+			message("GetLedgerRequest",
+				field("ledger", 1, msgType, "LedgerSpecifier"),
+				field("transactions", 2, boolType, ""),
+				field("expand", 3, boolType, ""),
+				field("get_objects", 4, boolType, ""),
+				field("client_ip", 5, stringType, ""),
+				field("user", 6, stringType, ""),
+				field("get_object_neighbors", 7, boolType, ""),
+			),
+			getLedgerResponse,
+			message("GetLedgerDataRequest",
+				field("ledger", 1, msgType, "LedgerSpecifier"),
+				field("marker", 2, bytesType, ""),
+				field("end_marker", 3, bytesType, ""),
+				field("client_ip", 4, stringType, ""),
+				field("user", 6, stringType, ""),
+			),
+			message("GetLedgerDataResponse",
+				field("ledger_index", 1, uint32Type, ""),
+				field("ledger_hash", 2, bytesType, ""),
+				field("ledger_objects", 3, msgType, "RawLedgerObjects"),
+				field("marker", 4, bytesType, ""),
+				field("is_unlimited", 5, boolType, ""),
+			),
+			message("GetLedgerEntryRequest",
+				field("key", 1, bytesType, ""),
+				field("ledger", 2, msgType, "LedgerSpecifier"),
+				field("client_ip", 3, stringType, ""),
+				field("user", 4, stringType, ""),
+			),
+			message("GetLedgerEntryResponse",
+				field("ledger_object", 1, msgType, "RawLedgerObject"),
+				field("ledger", 2, msgType, "LedgerSpecifier"),
+			),
+			message("GetLedgerDiffRequest",
+				field("base_ledger", 1, msgType, "LedgerSpecifier"),
+				field("desired_ledger", 2, msgType, "LedgerSpecifier"),
+				field("include_blobs", 3, boolType, ""),
+				field("client_ip", 4, stringType, ""),
+			),
+			message("GetLedgerDiffResponse", field("ledger_objects", 1, msgType, "RawLedgerObjects")),
+		},
+	}
+	fd, err := protodesc.NewFile(file, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return fd
+}
+
+// golden builds a message of the named type from a map of field names to
+// values: scalars, enums as numbers, nested maps for messages and slices
+// for repeated fields.
+func golden(t *testing.T, fd protoreflect.FileDescriptor, name string, fields map[string]interface{}) *dynamicpb.Message {
+	t.Helper()
+	desc := fd.Messages().ByName(protoreflect.Name(name))
+	if desc == nil {
+		t.Fatalf("no message %s", name)
+	}
+	return build(t, desc, fields)
+}
+
+func build(t *testing.T, desc protoreflect.MessageDescriptor, fields map[string]interface{}) *dynamicpb.Message {
+	m := dynamicpb.NewMessage(desc)
+	for name, v := range fields {
+		fd := desc.Fields().ByName(protoreflect.Name(name))
+		if fd == nil {
+			t.Fatalf("%s has no field %s", desc.Name(), name)
+		}
+		if fd.IsList() {
+			list := m.Mutable(fd).List()
+			for _, item := range v.([]interface{}) {
+				list.Append(value(t, fd, item))
+			}
+			continue
+		}
+		m.Set(fd, value(t, fd, v))
+	}
+	return m
+}
+
+func value(t *testing.T, fd protoreflect.FieldDescriptor, v interface{}) protoreflect.Value {
+	switch fd.Kind() {
+	case protoreflect.MessageKind:
+		return protoreflect.ValueOfMessage(build(t, fd.Message(), v.(map[string]interface{})))
+	case protoreflect.EnumKind:
+		return protoreflect.ValueOfEnum(protoreflect.EnumNumber(v.(int)))
+	case protoreflect.Uint32Kind:
+		return protoreflect.ValueOfUint32(uint32(v.(int)))
+	default:
+		return protoreflect.ValueOf(v)
+	}
+}
+
+func TestMessagesGolden(t *testing.T) {
+	fd := rippledFile(t)
+	hash := bytes.Repeat([]byte{0xAB}, 32)
+	key := bytes.Repeat([]byte{0xCD}, 32)
+	object := &RawLedgerObject{Data: []byte{0x11, 0x00, 0x61}, Key: key, ModType: ModificationModified, Predecessor: hash, Successor: key}
+	objectFields := map[string]interface{}{"data": []byte{0x11, 0x00, 0x61}, "key": key, "mod_type": 2, "predecessor": hash, "successor": key}
+
+	tests := []struct {
+		name   string
+		msg    message
+		empty  message
+		fields map[string]interface{}
+	}{
+		{
+			name:   "GetLedgerRequest",
+			msg:    &GetLedgerRequest{Ledger: ValidatedLedger(), Transactions: true, Expand: true, GetObjects: true, ClientIp: "127.0.0.1", User: "me", GetObjectNeighbors: true},
+			empty:  &GetLedgerRequest{},
+			fields: map[string]interface{}{"ledger": map[string]interface{}{"shortcut": 1}, "transactions": true, "expand": true, "get_objects": true, "client_ip": "127.0.0.1", "user": "me", "get_object_neighbors": true},
+		},
+		{
+			name:  "GetLedgerResponse",
+			msg:   &GetLedgerResponse{LedgerHeader: []byte{1, 2, 3}, Transactions: []*TransactionAndMetadata{{TransactionBlob: []byte{4}, MetadataBlob: []byte{5}}}, Validated: true, LedgerObjects: &RawLedgerObjects{Objects: []*RawLedgerObject{object}}, SkiplistIncluded: true, IsUnlimited: true, ObjectsIncluded: true, ObjectNeighborsIncluded: true, BookSuccessors: []*BookSuccessor{{BookBase: hash, BookSuccessor: key}}},
+			empty: &GetLedgerResponse{},
+			fields: map[string]interface{}{
+				"ledger_header":             []byte{1, 2, 3},
+				"transactions_list":         map[string]interface{}{"transactions": []interface{}{map[string]interface{}{"transaction_blob": []byte{4}, "metadata_blob": []byte{5}}}},
+				"validated":                 true,
+				"ledger_objects":            map[string]interface{}{"objects": []interface{}{objectFields}},
+				"skiplist_included":         true,
+				"is_unlimited":              true,
+				"objects_included":          true,
+				"object_neighbors_included": true,
+				"book_successors":           []interface{}{map[string]interface{}{"book_base": hash, "book_successor": key}},
+			},
+		},
+		{
+			name:   "GetLedgerResponse hashes",
+			msg:    &GetLedgerResponse{LedgerHeader: []byte{1}, TransactionHashes: [][]byte{hash, key}},
+			empty:  &GetLedgerResponse{},
+			fields: map[string]interface{}{"ledger_header": []byte{1}, "hashes_list": map[string]interface{}{"hashes": []interface{}{hash, key}}},
+		},
+		{
+			name:   "GetLedgerDataRequest",
+			msg:    &GetLedgerDataRequest{Ledger: LedgerBySequence(38129), Marker: key, EndMarker: hash, ClientIp: "127.0.0.1", User: "me"},
+			empty:  &GetLedgerDataRequest{},
+			fields: map[string]interface{}{"ledger": map[string]interface{}{"sequence": 38129}, "marker": key, "end_marker": hash, "client_ip": "127.0.0.1", "user": "me"},
+		},
+		{
+			name:   "GetLedgerDataResponse",
+			msg:    &GetLedgerDataResponse{LedgerIndex: 38129, LedgerHash: hash, LedgerObjects: &RawLedgerObjects{Objects: []*RawLedgerObject{object}}, Marker: key, IsUnlimited: true},
+			empty:  &GetLedgerDataResponse{},
+			fields: map[string]interface{}{"ledger_index": 38129, "ledger_hash": hash, "ledger_objects": map[string]interface{}{"objects": []interface{}{objectFields}}, "marker": key, "is_unlimited": true},
+		},
+		{
+			name:   "GetLedgerEntryRequest",
+			msg:    &GetLedgerEntryRequest{Key: key, Ledger: LedgerByHash(hash), ClientIp: "127.0.0.1", User: "me"},
+			empty:  &GetLedgerEntryRequest{},
+			fields: map[string]interface{}{"key": key, "ledger": map[string]interface{}{"hash": hash}, "client_ip": "127.0.0.1", "user": "me"},
+		},
+		{
+			name:   "GetLedgerEntryResponse",
+			msg:    &GetLedgerEntryResponse{LedgerObject: object, Ledger: LedgerBySequence(38129)},
+			empty:  &GetLedgerEntryResponse{},
+			fields: map[string]interface{}{"ledger_object": objectFields, "ledger": map[string]interface{}{"sequence": 38129}},
+		},
+		{
+			name:   "GetLedgerDiffRequest",
+			msg:    &GetLedgerDiffRequest{BaseLedger: LedgerBySequence(38128), DesiredLedger: LedgerBySequence(38129), IncludeBlobs: true, ClientIp: "127.0.0.1"},
+			empty:  &GetLedgerDiffRequest{},
+			fields: map[string]interface{}{"base_ledger": map[string]interface{}{"sequence": 38128}, "desired_ledger": map[string]interface{}{"sequence": 38129}, "include_blobs": true, "client_ip": "127.0.0.1"},
+		},
+		{
+			name:   "GetLedgerDiffResponse",
+			msg:    &GetLedgerDiffResponse{LedgerObjects: &RawLedgerObjects{Objects: []*RawLedgerObject{object, {Key: hash, ModType: ModificationDeleted}}}},
+			empty:  &GetLedgerDiffResponse{},
+			fields: map[string]interface{}{"ledger_objects": map[string]interface{}{"objects": []interface{}{objectFields, map[string]interface{}{"key": hash, "mod_type": 3}}}},
+		},
+	}
+	for _, test := range tests {
+		want := golden(t, fd, strings.Fields(test.name)[0], test.fields)
+		wire, err := proto.MarshalOptions{Deterministic: true}.Marshal(want)
+		if err != nil {
+			t.Fatal(err)
+		}
+		// protobuf-go writes oneof fields last and rippled in field order,
+		// so compare parsed messages rather than bytes. Misnumbered fields
+		// are kept as unknown fields and make the messages differ.
+		got := dynamicpb.NewMessage(want.Descriptor())
+		if err := proto.Unmarshal(test.msg.marshal(), got); err != nil {
+			t.Errorf("%s: %v", test.name, err)
+		} else if !proto.Equal(got, want) {
+			t.Errorf("%s: marshal\n got %v\nwant %v", test.name, got, want)
+		}
+		if err := test.empty.unmarshal(wire); err != nil {
+			t.Errorf("%s: unmarshal: %v", test.name, err)
+		} else if !reflect.DeepEqual(test.empty, test.msg) {
+			t.Errorf("%s: unmarshal\n got %+v\nwant %+v", test.name, test.empty, test.msg)
+		}
+	}
+}
@@ -0,0 +1,323 @@
+package grpcapi
+
+import (
+	"bytes"
+	"context"
+	"encoding/binary"
+	"net"
+	"sort"
+	"sync"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// LedgerServer is the server side of rippled's XRPLedgerAPIService.
+type LedgerServer interface {
+	GetLedger(context.Context, *GetLedgerRequest) (*GetLedgerResponse, error)
+	GetLedgerData(context.Context, *GetLedgerDataRequest) (*GetLedgerDataResponse, error)
+	GetLedgerEntry(context.Context, *GetLedgerEntryRequest) (*GetLedgerEntryResponse, error)
+	GetLedgerDiff(context.Context, *GetLedgerDiffRequest) (*GetLedgerDiffResponse, error)
+}
+
+// NewServer creates a grpc.Server that encodes messages with this package's
+// codec. Register a LedgerServer on it with RegisterLedgerServer.
+func NewServer(opts ...grpc.ServerOption) *grpc.Server {
+	opts = append(opts, grpc.ForceServerCodec(codec{}), grpc.MaxSendMsgSize(MaxMessageSize))
+	return grpc.NewServer(opts...)
+}
+
+func RegisterLedgerServer(s *grpc.Server, srv LedgerServer) {
+	s.RegisterService(&serviceDesc, srv)
+}
+
+var serviceDesc = grpc.ServiceDesc{
+	ServiceName: serviceName,
+	HandlerType: (*LedgerServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "GetLedger",
+			Handler: func(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+				req := &GetLedgerRequest{}
+				if err := dec(req); err != nil {
+					return nil, err
+				}
+				if interceptor == nil {
+					return srv.(LedgerServer).GetLedger(ctx, req)
+				}
+				info := &grpc.UnaryServerInfo{Server: srv, FullMethod: "/" + serviceName + "/GetLedger"}
+				handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+					return srv.(LedgerServer).GetLedger(ctx, req.(*GetLedgerRequest))
+				}
+				return interceptor(ctx, req, info, handler)
+			},
+		},
+		{
+			MethodName: "GetLedgerData",
+			Handler: func(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+				req := &GetLedgerDataRequest{}
+				if err := dec(req); err != nil {
+					return nil, err
+				}
+				if interceptor == nil {
+					return srv.(LedgerServer).GetLedgerData(ctx, req)
+				}
+				info := &grpc.UnaryServerInfo{Server: srv, FullMethod: "/" + serviceName + "/GetLedgerData"}
+				handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+					return srv.(LedgerServer).GetLedgerData(ctx, req.(*GetLedgerDataRequest))
+				}
+				return interceptor(ctx, req, info, handler)
+			},
+		},
+		{
+			MethodName: "GetLedgerEntry",
+			Handler: func(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+				req := &GetLedgerEntryRequest{}
+				if err := dec(req); err != nil {
+					return nil, err
+				}
+				if interceptor == nil {
+					return srv.(LedgerServer).GetLedgerEntry(ctx, req)
+				}
+				info := &grpc.UnaryServerInfo{Server: srv, FullMethod: "/" + serviceName + "/GetLedgerEntry"}
+				handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+					return srv.(LedgerServer).GetLedgerEntry(ctx, req.(*GetLedgerEntryRequest))
+				}
+				return interceptor(ctx, req, info, handler)
+			},
+		},
+		{
+			MethodName: "GetLedgerDiff",
+			Handler: func(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+				req := &GetLedgerDiffRequest{}
+				if err := dec(req); err != nil {
+					return nil, err
+				}
+				if interceptor == nil {
+					return srv.(LedgerServer).GetLedgerDiff(ctx, req)
+				}
+				info := &grpc.UnaryServerInfo{Server: srv, FullMethod: "/" + serviceName + "/GetLedgerDiff"}
+				handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+					return srv.(LedgerServer).GetLedgerDiff(ctx, req.(*GetLedgerDiffRequest))
+				}
+				return interceptor(ctx, req, info, handler)
+			},
+		},
+	},
+	Streams: []grpc.StreamDesc{},
+}
+
+// StandIn is an in-memory LedgerServer that stands in for rippled in tests.
+// Add ledgers with AddLedger, then serve it with Start.
+type StandIn struct {
+	// Number of ledger entries per GetLedgerData page. Default is 256.
+	PageSize int
+
+	mutex     sync.Mutex
+	ledgers   map[uint32]*GetLedgerResponse
+	state     map[uint32][]*RawLedgerObject
+	validated uint32
+}
+
+func NewStandIn() *StandIn {
+	return &StandIn{
+		PageSize: 256,
+		ledgers:  make(map[uint32]*GetLedgerResponse),
+		state:    make(map[uint32][]*RawLedgerObject),
+	}
+}
+
+// AddLedger stores a ledger and its complete state. The ledger sequence is
+// read from the first four bytes of ledger.LedgerHeader. The highest ledger
+// added is served as the validated ledger.
+func (s *StandIn) AddLedger(ledger *GetLedgerResponse, state []*RawLedgerObject) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	seq := binary.BigEndian.Uint32(ledger.LedgerHeader[0:4])
+	sorted := append([]*RawLedgerObject{}, state...)
+	sort.Slice(sorted, func(i, j int) bool {
+		return bytes.Compare(sorted[i].Key, sorted[j].Key) < 0
+	})
+	s.ledgers[seq] = ledger
+	s.state[seq] = sorted
+	if seq > s.validated {
+		s.validated = seq
+	}
+}
+
+// Start serves the stand-in on a random local port. It returns the address
+// to pass to NewClient and a function that stops the server.
+func (s *StandIn) Start() (string, func(), error) {
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		return "", nil, err
+	}
+	server := NewServer()
+	RegisterLedgerServer(server, s)
+	go server.Serve(listener)
+	return listener.Addr().String(), server.Stop, nil
+}
+
+func (s *StandIn) GetLedger(ctx context.Context, req *GetLedgerRequest) (*GetLedgerResponse, error) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	seq, err := s.resolve(req.Ledger)
+	if err != nil {
+		return nil, err
+	}
+	ledger := s.ledgers[seq]
+	res := &GetLedgerResponse{
+		LedgerHeader: ledger.LedgerHeader,
+		Validated:    seq <= s.validated,
+		IsUnlimited:  true,
+	}
+	if req.Transactions {
+		if req.Expand {
+			res.Transactions = ledger.Transactions
+			if res.Transactions == nil {
+				res.Transactions = []*TransactionAndMetadata{}
+			}
+		} else {
+			res.TransactionHashes = ledger.TransactionHashes
+			if res.TransactionHashes == nil {
+				res.TransactionHashes = [][]byte{}
+			}
+		}
+	}
+	if req.GetObjects {
+		res.LedgerObjects = ledger.LedgerObjects
+		res.ObjectsIncluded = true
+	}
+	return res, nil
+}
+
+func (s *StandIn) GetLedgerData(ctx context.Context, req *GetLedgerDataRequest) (*GetLedgerDataResponse, error) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	seq, err := s.resolve(req.Ledger)
+	if err != nil {
+		return nil, err
+	}
+	state := s.state[seq]
+	start := sort.Search(len(state), func(i int) bool {
+		return bytes.Compare(state[i].Key, req.Marker) >= 0
+	})
+	end := start + s.PageSize
+	if s.PageSize <= 0 {
+		end = len(state)
+	}
+
+	res := &GetLedgerDataResponse{
+		LedgerIndex:   seq,
+		LedgerHash:    ledgerHash(s.ledgers[seq]),
+		LedgerObjects: &RawLedgerObjects{},
+		IsUnlimited:   true,
+	}
+	for i := start; i < end && i < len(state); i++ {
+		if len(req.EndMarker) > 0 && bytes.Compare(state[i].Key, req.EndMarker) >= 0 {
+			return res, nil
+		}
+		res.LedgerObjects.Objects = append(res.LedgerObjects.Objects, state[i])
+	}
+	if end < len(state) {
+		res.Marker = state[end].Key
+	}
+	return res, nil
+}
+
+func (s *StandIn) GetLedgerEntry(ctx context.Context, req *GetLedgerEntryRequest) (*GetLedgerEntryResponse, error) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	seq, err := s.resolve(req.Ledger)
+	if err != nil {
+		return nil, err
+	}
+	if object := find(s.state[seq], req.Key); object != nil {
+		return &GetLedgerEntryResponse{
+			LedgerObject: object,
+			Ledger:       LedgerBySequence(seq),
+		}, nil
+	}
+	return nil, status.Error(codes.NotFound, "object not found")
+}
+
+func (s *StandIn) GetLedgerDiff(ctx context.Context, req *GetLedgerDiffRequest) (*GetLedgerDiffResponse, error) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	base, err := s.resolve(req.BaseLedger)
+	if err != nil {
+		return nil, err
+	}
+	desired, err := s.resolve(req.DesiredLedger)
+	if err != nil {
+		return nil, err
+	}
+
+	diff := &RawLedgerObjects{}
+	for _, o := range s.state[desired] {
+		old := find(s.state[base], o.Key)
+		if old != nil && bytes.Equal(old.Data, o.Data) {
+			continue
+		}
+		change := &RawLedgerObject{Key: o.Key, ModType: ModificationModified}
+		if old == nil {
+			change.ModType = ModificationCreated
+		}
+		if req.IncludeBlobs {
+			change.Data = o.Data
+		}
+		diff.Objects = append(diff.Objects, change)
+	}
+	for _, o := range s.state[base] {
+		if find(s.state[desired], o.Key) == nil {
+			diff.Objects = append(diff.Objects, &RawLedgerObject{Key: o.Key, ModType: ModificationDeleted})
+		}
+	}
+	return &GetLedgerDiffResponse{LedgerObjects: diff}, nil
+}
+
+// resolve returns the sequence of the ledger selected by spec.
+func (s *StandIn) resolve(spec *LedgerSpecifier) (uint32, error) {
+	if spec == nil || (spec.Sequence == 0 && len(spec.Hash) == 0) {
+		if s.validated == 0 {
+			return 0, status.Error(codes.NotFound, "ledger not found")
+		}
+		return s.validated, nil
+	}
+	if len(spec.Hash) > 0 {
+		for seq, ledger := range s.ledgers {
+			if bytes.Equal(ledgerHash(ledger), spec.Hash) {
+				return seq, nil
+			}
+		}
+		return 0, status.Error(codes.NotFound, "ledger not found")
+	}
+	if _, ok := s.ledgers[spec.Sequence]; !ok {
+		return 0, status.Error(codes.NotFound, "ledger not found")
+	}
+	return spec.Sequence, nil
+}
+
+// ledgerHash returns the hash appended to a serialized ledger header, if any.
+func ledgerHash(ledger *GetLedgerResponse) []byte {
+	if len(ledger.LedgerHeader) != ledgerHeaderSizeWithHash {
+		return nil
+	}
+	return ledger.LedgerHeader[ledgerHeaderSize:]
+}
+
+func find(state []*RawLedgerObject, key []byte) *RawLedgerObject {
+	i := sort.Search(len(state), func(i int) bool {
+		return bytes.Compare(state[i].Key, key) >= 0
+	})
+	if i < len(state) && bytes.Equal(state[i].Key, key) {
+		return state[i]
+	}
+	return nil
+}
@@ -1,8 +1,6 @@
 package xrpl
 
 import (
-	"encoding/json"
-	"fmt"
 	"log"
 	"time"
 
@@ -16,22 +14,22 @@ func (c *Client) handlePong(message string) error {
 	return nil
 }
 
-func (c *Client) handleResponse() error {
+// handleResponse reads messages from conn until the connection fails or done
+// is closed. An unexpected read error triggers a reconnect of this
+// connection, which runs in its own goroutine because it waits for this
+// handler to return.
+func (c *Client) handleResponse(conn *websocket.Conn, done chan struct{}) error {
 	defer c.wg.Done()
 	for {
-		select {
-		case <-c.handlerDone:
-			return nil
-		default:
-			if c.closed {
+		messageType, message, err := conn.ReadMessage()
+		if err != nil {
+			select {
+			case <-done:
 				return nil
+			default:
 			}
-		}
-
-		messageType, message, err := c.connection.ReadMessage()
-		if err != nil {
 			log.Println("WS read error:", err)
-			c.Reconnect()
+			go c.reconnect(done, false)
 			return nil
 		}
 
@@ -40,56 +38,71 @@ func (c *Client) handleResponse() error {
 			log.Println("WS websocket.CloseMessage received")
 			return nil
 		case websocket.TextMessage:
-			c.resolveStream(message)
+			c.resolveStream(message, done)
 		case websocket.BinaryMessage:
 		default:
 		}
 	}
 }
 
-func (c *Client) resolveStream(message []byte) {
-	var m BaseResponse
-	if err := json.Unmarshal(message, &m); err != nil {
-		log.Println("json.Unmarshal error: ", err)
+// resolveStream routes message to its stream channel. Only the top-level
+// type, id and ledger_index fields are read; the message itself is forwarded
+// undecoded. Sends are abandoned when done is closed, so a full channel never
+// blocks a disconnect.
+func (c *Client) resolveStream(message []byte, done chan struct{}) {
+	fields, err := scanFields(message, "type", "id", "ledger_index")
+	if err != nil {
+		log.Println("WS message scan error:", err)
+		fields = make([][]byte, 3)
 	}
 
-	switch m["type"] {
+	var stream chan []byte
+	switch rawString(fields[0]) {
 	case StreamResponseType(StreamTypeLedger):
-		c.StreamLedger <- message
+		if ledgerIndex, ok := rawUint(fields[2]); ok {
+			c.health.observeLedger(ledgerIndex)
+		}
+		stream = c.StreamLedger
 
 	case StreamResponseType(StreamTypeTransaction):
-		c.StreamTransaction <- message
+		stream = c.StreamTransaction
 
 	case StreamResponseType(StreamTypeValidations):
-		c.StreamValidation <- message
+		stream = c.StreamValidation
 
 	case StreamResponseType(StreamTypeManifests):
-		c.StreamManifest <- message
+		stream = c.StreamManifest
 
 	case StreamResponseType(StreamTypePeerStatus):
-		c.StreamPeerStatus <- message
+		stream = c.StreamPeerStatus
 
 	case StreamResponseType(StreamTypeConsensus):
-		c.StreamConsensus <- message
+		stream = c.StreamConsensus
 
 	case StreamResponseType(StreamTypePathFind):
-		c.StreamPathFind <- message
+		stream = c.StreamPathFind
 
 	case StreamResponseType(StreamTypeServer):
-		c.StreamServer <- message
+		stream = c.StreamServer
 
 	case StreamResponseType(StreamTypeResponse):
-		requestId := fmt.Sprintf("%v", m["id"])
+		requestId := rawString(fields[1])
 		c.mutex.Lock()
 		ch, ok := c.requestQueue[requestId]
 		if ok {
-			ch <- m
+			ch <- message
 			delete(c.requestQueue, requestId)
 			close(ch)
 		}
 		c.mutex.Unlock()
+		return
 
 	default:
-		c.StreamDefault <- message
+		stream = c.StreamDefault
+	}
+
+	select {
+	case stream <- message:
+	case <-done:
 	}
 }
@@ -6,14 +6,15 @@ import (
 
 // Heartbeat runner to send Pings periodically. If a Pong is received, it is
 // handled by handlePong handler which further extends websocket connection's
-// read and write deadline into the future.
-func (c *Client) heartbeat() {
+// read and write deadline into the future. The heartbeat stops when done is
+// closed.
+func (c *Client) heartbeat(done chan struct{}) {
 	defer c.wg.Done()
 	// log.Println("INF: Heartbeat started")
 	ticker := time.NewTicker(c.config.HeartbeatInterval)
 	for {
 		select {
-		case <-c.heartbeatDone:
+		case <-done:
 			ticker.Stop()
 			// log.Println("ERR: Heartbeat stopped")
 			return
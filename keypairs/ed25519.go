@@ -0,0 +1,39 @@
+package keypairs
+
+import (
+	"crypto/ed25519"
+)
+
+// ed25519Prefix marks ed25519 keys, which are 32 bytes long, so that they
+// have the same 33 byte length as compressed secp256k1 public keys.
+const ed25519Prefix = 0xED
+
+// deriveED25519 uses the SHA-512Half of the seed entropy as private key.
+func deriveED25519(entropy []byte) (string, string, error) {
+	private := Sha512Half(entropy)
+	public := ed25519.NewKeyFromSeed(private).Public().(ed25519.PublicKey)
+	return hexUpper(append([]byte{ed25519Prefix}, private...)),
+		hexUpper(append([]byte{ed25519Prefix}, public...)), nil
+}
+
+func publicKeyED25519(private []byte) ([]byte, error) {
+	if len(private) != ed25519.SeedSize {
+		return nil, ErrInvalidPrivateKey
+	}
+	public := ed25519.NewKeyFromSeed(private).Public().(ed25519.PublicKey)
+	return append([]byte{ed25519Prefix}, public...), nil
+}
+
+func signED25519(message []byte, private []byte) ([]byte, error) {
+	if len(private) != ed25519.SeedSize {
+		return nil, ErrInvalidPrivateKey
+	}
+	return ed25519.Sign(ed25519.NewKeyFromSeed(private), message), nil
+}
+
+func verifyED25519(message []byte, sig []byte, public []byte) bool {
+	if len(public) != ed25519.PublicKeySize || len(sig) != ed25519.SignatureSize {
+		return false
+	}
+	return ed25519.Verify(public, message, sig)
+}
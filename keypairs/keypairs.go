@@ -0,0 +1,159 @@
+// Package keypairs derives XRP Ledger key pairs from seeds, derives
+// addresses from public keys, and signs and verifies messages with
+// secp256k1 and ed25519 keys.
+//
+// Keys are uppercase hex strings, as in the SigningPubKey field of
+// transactions. ed25519 keys are prefixed with "ED", secp256k1 public keys
+// are 33 byte compressed points and secp256k1 private keys are prefixed
+// with "00".
+// https://xrpl.org/docs/concepts/accounts/cryptographic-keys
+package keypairs
+
+import (
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"strings"
+
+	"github.com/xrpscan/xrpl-go/addresscodec"
+	"github.com/xrpscan/xrpl-go/binarycodec"
+	"golang.org/x/crypto/ripemd160"
+)
+
+// Errors returned for keys that are not valid hex encoded keys of either
+// algorithm.
+var (
+	ErrInvalidPrivateKey = errors.New("invalid private key")
+	ErrInvalidPublicKey  = errors.New("invalid public key")
+)
+
+// GenerateSeed encodes entropy as a seed for the given algorithm. If
+// entropy is nil, 16 random bytes are used.
+func GenerateSeed(entropy []byte, algorithm addresscodec.Algorithm) (string, error) {
+	if entropy == nil {
+		entropy = make([]byte, addresscodec.SeedLength)
+		if _, err := rand.Read(entropy); err != nil {
+			return "", err
+		}
+	}
+	return addresscodec.EncodeSeed(entropy, algorithm)
+}
+
+// DeriveKeypair derives the account key pair of a seed. For secp256k1
+// seeds this is the key pair at account index 0 of the seed's family
+// generator.
+func DeriveKeypair(seed string) (privateKey string, publicKey string, err error) {
+	entropy, algorithm, err := addresscodec.DecodeSeed(seed)
+	if err != nil {
+		return "", "", err
+	}
+	if algorithm == addresscodec.ED25519 {
+		return deriveED25519(entropy)
+	}
+	return deriveSECP256K1(entropy, false)
+}
+
+// DeriveValidatorKeypair derives the root key pair of a secp256k1 seed, as
+// used by validators and peer nodes. ed25519 seeds derive the same key pair
+// as DeriveKeypair.
+func DeriveValidatorKeypair(seed string) (privateKey string, publicKey string, err error) {
+	entropy, algorithm, err := addresscodec.DecodeSeed(seed)
+	if err != nil {
+		return "", "", err
+	}
+	if algorithm == addresscodec.ED25519 {
+		return deriveED25519(entropy)
+	}
+	return deriveSECP256K1(entropy, true)
+}
+
+// DerivePublicKey returns the hex encoded public key of a hex encoded
+// secp256k1 or ed25519 private key.
+func DerivePublicKey(privateKey string) (string, error) {
+	key, err := hex.DecodeString(privateKey)
+	if err != nil {
+		return "", ErrInvalidPrivateKey
+	}
+	var public []byte
+	if isED25519(key) {
+		public, err = publicKeyED25519(key[1:])
+	} else {
+		public, err = publicKeySECP256K1(key)
+	}
+	if err != nil {
+		return "", err
+	}
+	return hexUpper(public), nil
+}
+
+// DeriveAccountID returns the AccountID of a public key: the RIPEMD-160
+// hash of its SHA-256 hash.
+func DeriveAccountID(publicKey []byte) []byte {
+	sha := sha256.Sum256(publicKey)
+	ripemd := ripemd160.New()
+	ripemd.Write(sha[:])
+	return ripemd.Sum(nil)
+}
+
+// DeriveAddress returns the classic address of a hex encoded public key.
+func DeriveAddress(publicKey string) (string, error) {
+	key, err := hex.DecodeString(publicKey)
+	if err != nil || len(key) != addresscodec.PublicKeyLength {
+		return "", ErrInvalidPublicKey
+	}
+	return addresscodec.EncodeAccountID(DeriveAccountID(key))
+}
+
+// Sign signs message with a hex encoded private key and returns the hex
+// encoded signature. secp256k1 signatures are DER encoded, with a low S
+// value, over the SHA-512Half of message; ed25519 signs message directly.
+func Sign(message []byte, privateKey string) (string, error) {
+	key, err := hex.DecodeString(privateKey)
+	if err != nil {
+		return "", ErrInvalidPrivateKey
+	}
+	var sig []byte
+	if isED25519(key) {
+		sig, err = signED25519(message, key[1:])
+	} else {
+		sig, err = signSECP256K1(message, key)
+	}
+	if err != nil {
+		return "", err
+	}
+	return strings.ToUpper(hex.EncodeToString(sig)), nil
+}
+
+// Verify reports whether signature is a valid signature of message by the
+// hex encoded public key. secp256k1 signatures must be canonical DER with a
+// low S value.
+func Verify(message []byte, signature string, publicKey string) bool {
+	key, err := hex.DecodeString(publicKey)
+	if err != nil {
+		return false
+	}
+	sig, err := hex.DecodeString(signature)
+	if err != nil {
+		return false
+	}
+	if isED25519(key) {
+		return verifyED25519(message, sig, key[1:])
+	}
+	return verifySECP256K1(message, sig, key)
+}
+
+// isED25519 reports whether a key carries the ed25519 prefix.
+func isED25519(key []byte) bool {
+	return len(key) == 33 && key[0] == 0xED
+}
+
+// Sha512Half returns the first 32 bytes of the SHA-512 hash of data, the
+// hash function used throughout the XRP Ledger. It is binarycodec.Sha512Half.
+func Sha512Half(data ...[]byte) []byte {
+	return binarycodec.Sha512Half(data...)
+}
+
+func hexUpper(b []byte) string {
+	return strings.ToUpper(hex.EncodeToString(b))
+}
@@ -0,0 +1,210 @@
+package keypairs
+
+import (
+	"bytes"
+	"encoding/hex"
+	"testing"
+
+	"github.com/decred/dcrd/dcrec/secp256k1/v4"
+	"github.com/decred/dcrd/dcrec/secp256k1/v4/ecdsa"
+	"github.com/xrpscan/xrpl-go/addresscodec"
+)
+
+// Vectors from xrpl.js's ripple-keypairs and the genesis account.
+var keypairTests = []struct {
+	seed       string
+	privateKey string
+	publicKey  string
+	address    string
+	signature  string // of "test message"
+}{
+	{
+		seed:       "snoPBrXtMeMyMHUVTgbuqAfg1SUTb",
+		privateKey: "001ACAAEDECE405B2A958212629E16F2EB46B153EEE94CDD350FDEFF52795525B7",
+		publicKey:  "0330E7FC9D56BB25D6893BA3F317AE5BCF33B3291BD63DB32654A313222F7FD020",
+		address:    "rHb9CJAWyB4rj91VRWn96DkukG4bwdtyTh",
+	},
+	{
+		seed:       "sp5fghtJtpUorTwvof1NpDXAzNwf5",
+		privateKey: "00D78B9735C3F26501C7337B8A5727FD53A6EFDBC6AA55984F098488561F985E23",
+		publicKey:  "030D58EB48B4420B1F7B9DF55087E0E29FEF0E8468F9A6825B01CA2C361042D435",
+		address:    "rU6K7V3Po4snVhBBaU29sesqs2qTQJWDw1",
+		signature:  "30440220583A91C95E54E6A651C47BEC22744E0B101E2C4060E7B08F6341657DAD9BC3EE02207D1489C7395DB0188D3A56A977ECBA54B36FA9371B40319655B1B4429E33EF2D",
+	},
+	{
+		seed:       "sEdSKaCy2JT7JaM7v95H9SxkhP9wS2r",
+		privateKey: "EDB4C4E046826BD26190D09715FC31F4E6A728204EADD112905B08B14B7F15C4F3",
+		publicKey:  "ED01FA53FA5A7E77798F882ECE20B1ABC00BB358A9E55A202D0D0676BD0CE37A63",
+		address:    "rLUEXYuLiQptky37CqLcm9USQpPiz5rkpD",
+		signature:  "CB199E1BFD4E3DAA105E4832EEDFA36413E1F44205E4EFB9E27E826044C21E3E2E848BBC8195E8959BADF887599B7310AD1B7047EF11B682E0D068F73749750E",
+	},
+}
+
+var testMessage = []byte("test message")
+
+func TestDeriveKeypair(t *testing.T) {
+	for _, test := range keypairTests {
+		privateKey, publicKey, err := DeriveKeypair(test.seed)
+		if err != nil {
+			t.Errorf("%s: %v", test.seed, err)
+			continue
+		}
+		if privateKey != test.privateKey || publicKey != test.publicKey {
+			t.Errorf("%s: got %s %s, want %s %s", test.seed, privateKey, publicKey, test.privateKey, test.publicKey)
+		}
+		address, err := DeriveAddress(publicKey)
+		if err != nil || address != test.address {
+			t.Errorf("%s: address %s (%v), want %s", test.seed, address, err, test.address)
+		}
+	}
+}
+
+func TestDerivePublicKey(t *testing.T) {
+	for _, test := range keypairTests {
+		publicKey, err := DerivePublicKey(test.privateKey)
+		if err != nil || publicKey != test.publicKey {
+			t.Errorf("%s: public key %s (%v), want %s", test.seed, publicKey, err, test.publicKey)
+		}
+		// secp256k1 private keys may also be given without the 00 prefix.
+		if test.privateKey[:2] == "00" {
+			if publicKey, err := DerivePublicKey(test.privateKey[2:]); err != nil || publicKey != test.publicKey {
+				t.Errorf("%s: public key %s (%v) without prefix", test.seed, publicKey, err)
+			}
+		}
+	}
+	if _, err := DerivePublicKey("ED00"); err == nil {
+		t.Error("derived a public key from a short key")
+	}
+}
+
+func TestSignVerify(t *testing.T) {
+	for i, test := range keypairTests {
+		sig, err := Sign(testMessage, test.privateKey)
+		if err != nil {
+			t.Errorf("%s: %v", test.seed, err)
+			continue
+		}
+		if test.signature != "" && sig != test.signature {
+			t.Errorf("%s: signature %s, want %s", test.seed, sig, test.signature)
+		}
+		if !Verify(testMessage, sig, test.publicKey) {
+			t.Errorf("%s: signature does not verify", test.seed)
+		}
+		if Verify([]byte("other message"), sig, test.publicKey) {
+			t.Errorf("%s: signature verifies for another message", test.seed)
+		}
+		other := keypairTests[(i+1)%len(keypairTests)]
+		if Verify(testMessage, sig, other.publicKey) {
+			t.Errorf("%s: signature verifies for another key", test.seed)
+		}
+	}
+}
+
+func TestHighSRejected(t *testing.T) {
+	test := keypairTests[1]
+	der, _ := hex.DecodeString(test.signature)
+	rLen := int(der[3])
+	rDER, sDER := der[4:4+rLen], der[6+rLen:]
+	var r, s secp256k1.ModNScalar
+	r.SetByteSlice(bytes.TrimLeft(rDER, "\x00"))
+	s.SetByteSlice(bytes.TrimLeft(sDER, "\x00"))
+	s.Negate()
+
+	// Serialize canonicalizes S, so build the high S DER by hand.
+	rBytes, sBytes := r.Bytes(), s.Bytes()
+	highDER := derSignature(rBytes[:], sBytes[:])
+	highS, err := ecdsa.ParseDERSignature(highDER)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !highS.Verify(Sha512Half(testMessage), mustParsePublicKey(t, test.publicKey)) {
+		t.Fatal("negated signature should verify mathematically")
+	}
+	if Verify(testMessage, hex.EncodeToString(highDER), test.publicKey) {
+		t.Error("high S signature verified")
+	}
+	normalized, err := NormalizeSignature(highDER)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !bytes.Equal(normalized, der) {
+		t.Errorf("normalized %X, want %X", normalized, der)
+	}
+
+	// 64 byte R || S form, as returned by HSMs.
+	raw := append(rBytes[:], sBytes[:]...)
+	normalized, err = NormalizeSignature(raw)
+	if err != nil || !bytes.Equal(normalized, der) {
+		t.Errorf("normalized R||S to %X (%v), want %X", normalized, err, der)
+	}
+}
+
+func TestSignDigest(t *testing.T) {
+	test := keypairTests[1]
+	sig, err := SignDigest(Sha512Half(testMessage), test.privateKey)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if hexUpper(sig) != test.signature {
+		t.Errorf("got %X, want %s", sig, test.signature)
+	}
+	if _, err := SignDigest(testMessage, test.privateKey); err == nil {
+		t.Error("signed a digest that is not 32 bytes")
+	}
+}
+
+func TestGenerateSeed(t *testing.T) {
+	for _, algorithm := range []addresscodec.Algorithm{addresscodec.SECP256K1, addresscodec.ED25519} {
+		entropy := bytes.Repeat([]byte{7}, addresscodec.SeedLength)
+		seed, err := GenerateSeed(entropy, algorithm)
+		if err != nil {
+			t.Fatal(err)
+		}
+		decoded, decodedAlgorithm, err := addresscodec.DecodeSeed(seed)
+		if err != nil || !bytes.Equal(decoded, entropy) || decodedAlgorithm != algorithm {
+			t.Errorf("%s: decoded %X %v (%v)", seed, decoded, decodedAlgorithm, err)
+		}
+		random, err := GenerateSeed(nil, algorithm)
+		if err != nil || random == seed {
+			t.Errorf("random seed %s (%v)", random, err)
+		}
+	}
+}
+
+func TestInvalidKeys(t *testing.T) {
+	if _, err := DeriveAddress("0330E7"); err == nil {
+		t.Error("derived an address from a short key")
+	}
+	if _, err := Sign(testMessage, "zz"); err == nil {
+		t.Error("signed with a non-hex key")
+	}
+	if Verify(testMessage, "3044", keypairTests[0].publicKey) {
+		t.Error("verified a truncated signature")
+	}
+	if _, _, err := DeriveKeypair("sp5fghtJtpUorTwvof1NpDXAzNwf6"); err == nil {
+		t.Error("derived a key pair from a seed with a bad checksum")
+	}
+}
+
+func mustParsePublicKey(t *testing.T, publicKey string) *secp256k1.PublicKey {
+	t.Helper()
+	key, _ := hex.DecodeString(publicKey)
+	public, err := secp256k1.ParsePubKey(key)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return public
+}
+
+// derSignature encodes R and S as a DER sequence without normalizing S.
+func derSignature(r, s []byte) []byte {
+	integer := func(b []byte) []byte {
+		b = bytes.TrimLeft(b, "\x00")
+		if b[0]&0x80 != 0 {
+			b = append([]byte{0}, b...)
+		}
+		return append([]byte{0x02, byte(len(b))}, b...)
+	}
+	body := append(integer(r), integer(s)...)
+	return append([]byte{0x30, byte(len(body))}, body...)
+}
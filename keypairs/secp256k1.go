@@ -0,0 +1,132 @@
+package keypairs
+
+import (
+	"bytes"
+	"encoding/binary"
+	"encoding/hex"
+	"errors"
+
+	"github.com/decred/dcrd/dcrec/secp256k1/v4"
+	"github.com/decred/dcrd/dcrec/secp256k1/v4/ecdsa"
+)
+
+// deriveSECP256K1 implements rippled's family generator. The root private
+// key is derived from the seed entropy. Account keys add a scalar derived
+// from the root public key and the account index, which is always 0.
+func deriveSECP256K1(entropy []byte, validator bool) (string, string, error) {
+	root := deriveScalar(entropy, nil)
+	private := root
+	if !validator {
+		rootPublic := secp256k1.NewPrivateKey(&root).PubKey().SerializeCompressed()
+		accountIndex := make([]byte, 4)
+		intermediate := deriveScalar(rootPublic, accountIndex)
+		private.Add(&intermediate)
+	}
+
+	key := secp256k1.NewPrivateKey(&private)
+	privateBytes := private.Bytes()
+	return hexUpper(append([]byte{0x00}, privateBytes[:]...)),
+		hexUpper(key.PubKey().SerializeCompressed()), nil
+}
+
+// deriveScalar hashes bytes, an optional discriminator and an incrementing
+// sequence number until the result is a valid secp256k1 private key.
+func deriveScalar(bytes []byte, discriminator []byte) secp256k1.ModNScalar {
+	var scalar secp256k1.ModNScalar
+	sequence := make([]byte, 4)
+	for i := uint32(0); ; i++ {
+		binary.BigEndian.PutUint32(sequence, i)
+		hash := Sha512Half(bytes, discriminator, sequence)
+		overflow := scalar.SetByteSlice(hash)
+		if !overflow && !scalar.IsZero() {
+			return scalar
+		}
+	}
+}
+
+func parseSECP256K1PrivateKey(key []byte) (*secp256k1.PrivateKey, error) {
+	// Private keys may carry a 00 prefix to make them 33 bytes long
+	if len(key) == 33 && key[0] == 0x00 {
+		key = key[1:]
+	}
+	if len(key) != 32 {
+		return nil, ErrInvalidPrivateKey
+	}
+	var scalar secp256k1.ModNScalar
+	if overflow := scalar.SetByteSlice(key); overflow || scalar.IsZero() {
+		return nil, ErrInvalidPrivateKey
+	}
+	return secp256k1.NewPrivateKey(&scalar), nil
+}
+
+func publicKeySECP256K1(key []byte) ([]byte, error) {
+	private, err := parseSECP256K1PrivateKey(key)
+	if err != nil {
+		return nil, err
+	}
+	return private.PubKey().SerializeCompressed(), nil
+}
+
+// signSECP256K1 produces a deterministic (RFC 6979) DER signature of the
+// SHA-512Half of message. The signature always has a low S value.
+func signSECP256K1(message []byte, key []byte) ([]byte, error) {
+	private, err := parseSECP256K1PrivateKey(key)
+	if err != nil {
+		return nil, err
+	}
+	return ecdsa.Sign(private, Sha512Half(message)).Serialize(), nil
+}
+
+func verifySECP256K1(message []byte, sig []byte, key []byte) bool {
+	public, err := secp256k1.ParsePubKey(key)
+	if err != nil {
+		return false
+	}
+	signature, err := ecdsa.ParseDERSignature(sig)
+	if err != nil {
+		return false
+	}
+	// Require fully canonical signatures, as rippled does. Serialize
+	// always produces strict DER with a low S value.
+	if !bytes.Equal(signature.Serialize(), sig) {
+		return false
+	}
+	return signature.Verify(Sha512Half(message), public)
+}
+
+// SignDigest signs a 32 byte digest, such as the SHA-512Half of a message,
+// with a hex encoded secp256k1 private key. Returns the DER encoded
+// signature with a low S value.
+func SignDigest(digest []byte, privateKey string) ([]byte, error) {
+	key, err := hex.DecodeString(privateKey)
+	if err != nil {
+		return nil, ErrInvalidPrivateKey
+	}
+	private, err := parseSECP256K1PrivateKey(key)
+	if err != nil {
+		return nil, err
+	}
+	if len(digest) != 32 {
+		return nil, errors.New("digest must be 32 bytes")
+	}
+	return ecdsa.Sign(private, digest).Serialize(), nil
+}
+
+// NormalizeSignature converts a secp256k1 signature, either DER encoded or
+// as the 64 byte concatenation of R and S returned by most HSMs and key
+// management services, to the canonical form rippled requires: strict DER
+// with a low S value.
+func NormalizeSignature(sig []byte) ([]byte, error) {
+	if len(sig) == 64 {
+		var r, s secp256k1.ModNScalar
+		if r.SetByteSlice(sig[:32]) || s.SetByteSlice(sig[32:]) || r.IsZero() || s.IsZero() {
+			return nil, errors.New("invalid signature")
+		}
+		return ecdsa.NewSignature(&r, &s).Serialize(), nil
+	}
+	signature, err := ecdsa.ParseDERSignature(sig)
+	if err != nil {
+		return nil, err
+	}
+	return signature.Serialize(), nil
+}
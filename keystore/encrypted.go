@@ -0,0 +1,244 @@
+// Package keystore keeps wallets on disk encrypted with a passphrase, so
+// that seeds need not be stored in plain text or environment variables.
+// Secrets are sealed with XChaCha20-Poly1305 under a key derived from the
+// passphrase with scrypt or Argon2id.
+package keystore
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/xrpscan/xrpl-go/addresscodec"
+	"github.com/xrpscan/xrpl-go/wallet"
+	"golang.org/x/crypto/argon2"
+	"golang.org/x/crypto/chacha20poly1305"
+	"golang.org/x/crypto/scrypt"
+)
+
+// KDF is the function that derives the encryption key from a passphrase.
+type KDF string
+
+// Supported KDFs
+const (
+	Scrypt   KDF = "scrypt"
+	Argon2id KDF = "argon2id"
+)
+
+// Version of the encrypted wallet format.
+const Version = 1
+
+const cipherName = "xchacha20-poly1305"
+
+// ErrWrongPassphrase is returned when a wallet cannot be decrypted with the
+// given passphrase.
+var ErrWrongPassphrase = errors.New("wrong passphrase")
+
+// EncryptedWallet is a wallet sealed with a passphrase, together with
+// metadata that can be read without it.
+type EncryptedWallet struct {
+	Version   int       `json:"version"`
+	Address   string    `json:"address"`
+	PublicKey string    `json:"public_key"`
+	KeyType   string    `json:"key_type"`
+	Label     string    `json:"label,omitempty"`
+	Created   time.Time `json:"created"`
+	Crypto    Crypto    `json:"crypto"`
+}
+
+// Crypto holds the parameters needed to decrypt a wallet.
+type Crypto struct {
+	KDF        KDF       `json:"kdf"`
+	KDFParams  KDFParams `json:"kdf_params"`
+	Cipher     string    `json:"cipher"`
+	Nonce      string    `json:"nonce"`
+	Ciphertext string    `json:"ciphertext"`
+}
+
+// KDFParams are the parameters of the key derivation. N, R and P apply to
+// scrypt; Time, Memory (in KiB) and Threads to Argon2id.
+type KDFParams struct {
+	Salt    string `json:"salt"`
+	N       int    `json:"n,omitempty"`
+	R       int    `json:"r,omitempty"`
+	P       int    `json:"p,omitempty"`
+	Time    uint32 `json:"time,omitempty"`
+	Memory  uint32 `json:"memory,omitempty"`
+	Threads uint8  `json:"threads,omitempty"`
+}
+
+// secret is the plaintext of an encrypted wallet.
+type secret struct {
+	Seed       string `json:"seed,omitempty"`
+	PrivateKey string `json:"private_key"`
+}
+
+// Encrypt seals w with passphrase. The seed is stored if the wallet has
+// one, otherwise the private key.
+func Encrypt(w *wallet.Wallet, passphrase string, label string, kdf KDF) (*EncryptedWallet, error) {
+	params, err := defaultParams(kdf)
+	if err != nil {
+		return nil, err
+	}
+	key, err := deriveKey(passphrase, kdf, params)
+	if err != nil {
+		return nil, err
+	}
+	aead, err := chacha20poly1305.NewX(key)
+	if err != nil {
+		return nil, err
+	}
+	nonce := make([]byte, aead.NonceSize())
+	if _, err := rand.Read(nonce); err != nil {
+		return nil, err
+	}
+
+	plaintext, err := json.Marshal(secret{Seed: w.Seed, PrivateKey: w.PrivateKey})
+	if err != nil {
+		return nil, err
+	}
+	e := &EncryptedWallet{
+		Version:   Version,
+		Address:   w.ClassicAddress,
+		PublicKey: w.PublicKey,
+		KeyType:   string(keyType(w.PublicKey)),
+		Label:     label,
+		Created:   time.Now().UTC().Truncate(time.Second),
+	}
+	ciphertext := aead.Seal(nil, nonce, plaintext, e.additionalData())
+	e.Crypto = Crypto{
+		KDF:        kdf,
+		KDFParams:  params,
+		Cipher:     cipherName,
+		Nonce:      hex.EncodeToString(nonce),
+		Ciphertext: hex.EncodeToString(ciphertext),
+	}
+	return e, nil
+}
+
+// Decrypt opens the wallet with passphrase and checks that it matches the
+// address and public key it is stored under.
+func (e *EncryptedWallet) Decrypt(passphrase string) (*wallet.Wallet, error) {
+	if e.Version != Version {
+		return nil, fmt.Errorf("unsupported keystore version %d", e.Version)
+	}
+	if e.Crypto.Cipher != cipherName {
+		return nil, fmt.Errorf("unsupported cipher %q", e.Crypto.Cipher)
+	}
+	key, err := deriveKey(passphrase, e.Crypto.KDF, e.Crypto.KDFParams)
+	if err != nil {
+		return nil, err
+	}
+	aead, err := chacha20poly1305.NewX(key)
+	if err != nil {
+		return nil, err
+	}
+	nonce, err := hex.DecodeString(e.Crypto.Nonce)
+	if err != nil || len(nonce) != aead.NonceSize() {
+		return nil, errors.New("invalid nonce")
+	}
+	ciphertext, err := hex.DecodeString(e.Crypto.Ciphertext)
+	if err != nil {
+		return nil, err
+	}
+	plaintext, err := aead.Open(nil, nonce, ciphertext, e.additionalData())
+	if err != nil {
+		return nil, ErrWrongPassphrase
+	}
+
+	var s secret
+	if err := json.Unmarshal(plaintext, &s); err != nil {
+		return nil, err
+	}
+	var w *wallet.Wallet
+	if s.Seed != "" {
+		w, err = wallet.FromSeed(s.Seed)
+	} else {
+		w, err = wallet.FromKeypair(s.PrivateKey, e.PublicKey)
+	}
+	if err != nil {
+		return nil, err
+	}
+	if w.ClassicAddress != e.Address || !strings.EqualFold(w.PublicKey, e.PublicKey) {
+		return nil, errors.New("decrypted wallet does not match its address")
+	}
+	return w, nil
+}
+
+// additionalData binds the ciphertext to the wallet's address and key, so
+// that the metadata cannot be swapped between files.
+func (e *EncryptedWallet) additionalData() []byte {
+	return []byte(e.Address + ":" + strings.ToUpper(e.PublicKey))
+}
+
+// Parameters of newly encrypted wallets, without the salt.
+var (
+	scryptParams   = KDFParams{N: 1 << 17, R: 8, P: 1}
+	argon2idParams = KDFParams{Time: 3, Memory: 64 * 1024, Threads: 4}
+)
+
+func defaultParams(kdf KDF) (KDFParams, error) {
+	var params KDFParams
+	switch kdf {
+	case Scrypt:
+		params = scryptParams
+	case Argon2id:
+		params = argon2idParams
+	default:
+		return params, fmt.Errorf("unsupported KDF %q", kdf)
+	}
+	salt := make([]byte, 32)
+	if _, err := rand.Read(salt); err != nil {
+		return KDFParams{}, err
+	}
+	params.Salt = hex.EncodeToString(salt)
+	return params, nil
+}
+
+// Upper bounds on the KDF parameters read from wallet files, so that a
+// corrupt or hostile file cannot exhaust memory or CPU. They are well above
+// the defaults.
+const (
+	maxKDFMemory  = 1 << 30 // Bytes
+	maxScryptN    = 1 << 20
+	maxScryptR    = 32
+	maxScryptP    = 16
+	maxArgon2Time = 16
+	maxThreads    = 64
+)
+
+func deriveKey(passphrase string, kdf KDF, params KDFParams) ([]byte, error) {
+	salt, err := hex.DecodeString(params.Salt)
+	if err != nil || len(salt) == 0 {
+		return nil, errors.New("invalid salt")
+	}
+	switch kdf {
+	case Scrypt:
+		if params.N <= 1 || params.N > maxScryptN || params.N&(params.N-1) != 0 ||
+			params.R <= 0 || params.R > maxScryptR || params.P <= 0 || params.P > maxScryptP ||
+			128*params.N*params.R > maxKDFMemory {
+			return nil, errors.New("invalid scrypt parameters")
+		}
+		return scrypt.Key([]byte(passphrase), salt, params.N, params.R, params.P, chacha20poly1305.KeySize)
+	case Argon2id:
+		if params.Time == 0 || params.Time > maxArgon2Time || params.Memory == 0 ||
+			uint64(params.Memory)*1024 > maxKDFMemory || params.Threads == 0 || params.Threads > maxThreads {
+			return nil, errors.New("invalid Argon2id parameters")
+		}
+		return argon2.IDKey([]byte(passphrase), salt, params.Time, params.Memory, params.Threads, chacha20poly1305.KeySize), nil
+	default:
+		return nil, fmt.Errorf("unsupported KDF %q", kdf)
+	}
+}
+
+// keyType returns the algorithm of a hex encoded public key.
+func keyType(publicKey string) addresscodec.Algorithm {
+	if strings.HasPrefix(strings.ToUpper(publicKey), "ED") {
+		return addresscodec.ED25519
+	}
+	return addresscodec.SECP256K1
+}
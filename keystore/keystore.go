@@ -0,0 +1,205 @@
+package keystore
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+
+	"github.com/xrpscan/xrpl-go/addresscodec"
+	"github.com/xrpscan/xrpl-go/wallet"
+)
+
+var (
+	// ErrNotFound is returned for addresses that are not in the keystore.
+	ErrNotFound = errors.New("wallet not found in keystore")
+
+	// ErrLocked is returned when signing with a wallet that is not
+	// unlocked.
+	ErrLocked = errors.New("wallet is locked")
+)
+
+// Keystore is a directory of encrypted wallets, one JSON file per address.
+// Unlocked wallets are held in memory until they are locked again. A
+// Keystore is safe for concurrent use.
+type Keystore struct {
+	// KDF is used for wallets added to the keystore. Defaults to Scrypt.
+	KDF KDF
+
+	dir      string
+	mutex    sync.Mutex
+	unlocked map[string]*wallet.Wallet
+}
+
+// Open opens the keystore in dir, creating the directory if needed.
+func Open(dir string) (*Keystore, error) {
+	if err := os.MkdirAll(dir, 0o700); err != nil {
+		return nil, err
+	}
+	return &Keystore{
+		KDF:      Scrypt,
+		dir:      dir,
+		unlocked: make(map[string]*wallet.Wallet),
+	}, nil
+}
+
+// Add encrypts w with passphrase and stores it. Existing wallets are not
+// overwritten.
+func (k *Keystore) Add(w *wallet.Wallet, passphrase string, label string) (*EncryptedWallet, error) {
+	e, err := Encrypt(w, passphrase, label, k.KDF)
+	if err != nil {
+		return nil, err
+	}
+	data, err := json.MarshalIndent(e, "", "  ")
+	if err != nil {
+		return nil, err
+	}
+
+	path := k.path(w.ClassicAddress)
+
+	// Write to a temporary file and link it into place, so that a crash
+	// cannot leave a truncated wallet file behind and an existing wallet
+	// is never replaced.
+	tmp, err := os.CreateTemp(k.dir, filepath.Base(path)+".*")
+	if err != nil {
+		return nil, err
+	}
+	defer os.Remove(tmp.Name())
+	if _, err := tmp.Write(data); err != nil {
+		tmp.Close()
+		return nil, err
+	}
+	if err := tmp.Sync(); err != nil {
+		tmp.Close()
+		return nil, err
+	}
+	if err := tmp.Close(); err != nil {
+		return nil, err
+	}
+	if err := os.Link(tmp.Name(), path); err != nil {
+		if errors.Is(err, os.ErrExist) {
+			return nil, fmt.Errorf("wallet %s is already in the keystore", w.ClassicAddress)
+		}
+		return nil, err
+	}
+	return e, nil
+}
+
+// Get returns the encrypted wallet of an address.
+func (k *Keystore) Get(address string) (*EncryptedWallet, error) {
+	if !addresscodec.IsValidClassicAddress(address) {
+		return nil, fmt.Errorf("invalid address: %q", address)
+	}
+	data, err := os.ReadFile(k.path(address))
+	if err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			return nil, ErrNotFound
+		}
+		return nil, err
+	}
+	e := &EncryptedWallet{}
+	if err := json.Unmarshal(data, e); err != nil {
+		return nil, err
+	}
+	if e.Address != address {
+		return nil, fmt.Errorf("keystore file for %s holds %s", address, e.Address)
+	}
+	return e, nil
+}
+
+// List returns the metadata of every wallet in the keystore, ordered by
+// creation time.
+func (k *Keystore) List() ([]*EncryptedWallet, error) {
+	files, err := os.ReadDir(k.dir)
+	if err != nil {
+		return nil, err
+	}
+	var wallets []*EncryptedWallet
+	for _, f := range files {
+		address, ok := strings.CutSuffix(f.Name(), ".json")
+		if !ok || f.IsDir() || !addresscodec.IsValidClassicAddress(address) {
+			continue
+		}
+		e, err := k.Get(address)
+		if err != nil {
+			return nil, err
+		}
+		wallets = append(wallets, e)
+	}
+	sort.SliceStable(wallets, func(i, j int) bool {
+		return wallets[i].Created.Before(wallets[j].Created)
+	})
+	return wallets, nil
+}
+
+// Remove deletes a wallet from the keystore and locks it.
+func (k *Keystore) Remove(address string) error {
+	if !addresscodec.IsValidClassicAddress(address) {
+		return fmt.Errorf("invalid address: %q", address)
+	}
+	k.mutex.Lock()
+	defer k.mutex.Unlock()
+	delete(k.unlocked, address)
+	err := os.Remove(k.path(address))
+	if errors.Is(err, os.ErrNotExist) {
+		return ErrNotFound
+	}
+	return err
+}
+
+// Unlock decrypts a wallet and keeps it in memory for signing.
+func (k *Keystore) Unlock(address string, passphrase string) error {
+	e, err := k.Get(address)
+	if err != nil {
+		return err
+	}
+	w, err := e.Decrypt(passphrase)
+	if err != nil {
+		return err
+	}
+	k.mutex.Lock()
+	k.unlocked[address] = w
+	k.mutex.Unlock()
+	return nil
+}
+
+// Lock forgets the decrypted keys of a wallet.
+func (k *Keystore) Lock(address string) {
+	k.mutex.Lock()
+	delete(k.unlocked, address)
+	k.mutex.Unlock()
+}
+
+// LockAll forgets the decrypted keys of every wallet.
+func (k *Keystore) LockAll() {
+	k.mutex.Lock()
+	k.unlocked = make(map[string]*wallet.Wallet)
+	k.mutex.Unlock()
+}
+
+// Unlocked reports whether a wallet is unlocked.
+func (k *Keystore) Unlocked(address string) bool {
+	k.mutex.Lock()
+	defer k.mutex.Unlock()
+	_, ok := k.unlocked[address]
+	return ok
+}
+
+// Sign single-signs tx with an unlocked wallet, like wallet.Sign.
+func (k *Keystore) Sign(address string, tx map[string]interface{}) (txBlob string, hash string, err error) {
+	k.mutex.Lock()
+	w, ok := k.unlocked[address]
+	k.mutex.Unlock()
+	if !ok {
+		return "", "", ErrLocked
+	}
+	return wallet.Sign(tx, w)
+}
+
+func (k *Keystore) path(address string) string {
+	return filepath.Join(k.dir, address+".json")
+}
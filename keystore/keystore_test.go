@@ -0,0 +1,210 @@
+package keystore
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/xrpscan/xrpl-go/wallet"
+)
+
+const (
+	secpSeed    = "sp5fghtJtpUorTwvof1NpDXAzNwf5"
+	ed25519Seed = "sEdSKaCy2JT7JaM7v95H9SxkhP9wS2r"
+)
+
+// reduceParams makes the KDFs cheap for the duration of a test.
+func reduceParams(t *testing.T) {
+	t.Helper()
+	scrypt, argon2id := scryptParams, argon2idParams
+	scryptParams = KDFParams{N: 1 << 4, R: 8, P: 1}
+	argon2idParams = KDFParams{Time: 1, Memory: 64, Threads: 1}
+	t.Cleanup(func() { scryptParams, argon2idParams = scrypt, argon2id })
+}
+
+func mustWallet(t *testing.T, seed string) *wallet.Wallet {
+	t.Helper()
+	w, err := wallet.FromSeed(seed)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return w
+}
+
+func testPayment(account string) map[string]interface{} {
+	return map[string]interface{}{
+		"TransactionType": "Payment",
+		"Account":         account,
+		"Destination":     "rHb9CJAWyB4rj91VRWn96DkukG4bwdtyTh",
+		"Amount":          "1000000",
+		"Fee":             "12",
+		"Sequence":        7,
+	}
+}
+
+func TestKeystore(t *testing.T) {
+	reduceParams(t)
+	for _, kdf := range []KDF{Scrypt, Argon2id} {
+		k, err := Open(filepath.Join(t.TempDir(), "keys"))
+		if err != nil {
+			t.Fatal(err)
+		}
+		k.KDF = kdf
+
+		wallets := []*wallet.Wallet{mustWallet(t, secpSeed), mustWallet(t, ed25519Seed)}
+		for i, w := range wallets {
+			e, err := k.Add(w, "passphrase", w.ClassicAddress)
+			if err != nil {
+				t.Fatalf("%s: %v", kdf, err)
+			}
+			if e.Crypto.KDF != kdf || e.KeyType != []string{"secp256k1", "ed25519"}[i] {
+				t.Errorf("%s: encrypted with %s as %s", kdf, e.Crypto.KDF, e.KeyType)
+			}
+			if _, err := k.Add(w, "other", ""); err == nil {
+				t.Errorf("%s: overwrote %s", kdf, w.ClassicAddress)
+			}
+		}
+		if e, err := k.Get(wallets[0].ClassicAddress); err != nil || e.Label != wallets[0].ClassicAddress {
+			t.Errorf("%s: Get: %+v %v", kdf, e, err)
+		}
+
+		listed, err := k.List()
+		if err != nil {
+			t.Fatal(err)
+		}
+		if len(listed) != len(wallets) {
+			t.Fatalf("%s: listed %d wallets", kdf, len(listed))
+		}
+		files, err := os.ReadDir(k.dir)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if len(files) != len(wallets) {
+			t.Errorf("%s: Add left temporary files behind: %d files", kdf, len(files))
+		}
+
+		for _, w := range wallets {
+			tx := testPayment(w.ClassicAddress)
+			if _, _, err := k.Sign(w.ClassicAddress, tx); !errors.Is(err, ErrLocked) {
+				t.Errorf("%s: signed while locked: %v", kdf, err)
+			}
+			if err := k.Unlock(w.ClassicAddress, "wrong"); !errors.Is(err, ErrWrongPassphrase) {
+				t.Errorf("%s: wrong passphrase: got %v, want %v", kdf, err, ErrWrongPassphrase)
+			}
+			if err := k.Unlock(w.ClassicAddress, "passphrase"); err != nil {
+				t.Fatalf("%s: %v", kdf, err)
+			}
+			if !k.Unlocked(w.ClassicAddress) {
+				t.Errorf("%s: %s not unlocked", kdf, w.ClassicAddress)
+			}
+			wantBlob, wantHash, err := wallet.Sign(tx, w)
+			if err != nil {
+				t.Fatal(err)
+			}
+			txBlob, hash, err := k.Sign(w.ClassicAddress, tx)
+			if err != nil || txBlob != wantBlob || hash != wantHash {
+				t.Errorf("%s: signed %s %s %v, want %s %s", kdf, hash, txBlob, err, wantHash, wantBlob)
+			}
+			k.Lock(w.ClassicAddress)
+			if _, _, err := k.Sign(w.ClassicAddress, tx); !errors.Is(err, ErrLocked) {
+				t.Errorf("%s: signed after Lock: %v", kdf, err)
+			}
+		}
+
+		if err := k.Remove(wallets[0].ClassicAddress); err != nil {
+			t.Fatal(err)
+		}
+		if _, err := k.Get(wallets[0].ClassicAddress); !errors.Is(err, ErrNotFound) {
+			t.Errorf("%s: Get after Remove: %v", kdf, err)
+		}
+		if err := k.Remove(wallets[0].ClassicAddress); !errors.Is(err, ErrNotFound) {
+			t.Errorf("%s: Remove twice: %v", kdf, err)
+		}
+	}
+}
+
+func TestEncryptedWalletBinding(t *testing.T) {
+	reduceParams(t)
+	secp, ed := mustWallet(t, secpSeed), mustWallet(t, ed25519Seed)
+	a, err := Encrypt(secp, "passphrase", "", Scrypt)
+	if err != nil {
+		t.Fatal(err)
+	}
+	b, err := Encrypt(ed, "passphrase", "", Scrypt)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// The address and public key are authenticated with the ciphertext.
+	swapped := *a
+	swapped.Address, swapped.PublicKey = b.Address, b.PublicKey
+	if _, err := swapped.Decrypt("passphrase"); !errors.Is(err, ErrWrongPassphrase) {
+		t.Errorf("swapped address and key: got %v, want %v", err, ErrWrongPassphrase)
+	}
+	swapped = *a
+	swapped.Crypto = b.Crypto
+	if _, err := swapped.Decrypt("passphrase"); !errors.Is(err, ErrWrongPassphrase) {
+		t.Errorf("swapped ciphertext: got %v, want %v", err, ErrWrongPassphrase)
+	}
+
+	// A wallet file stored under another address is rejected.
+	k, err := Open(t.TempDir())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := k.Add(ed, "passphrase", ""); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.Rename(k.path(ed.ClassicAddress), k.path(secp.ClassicAddress)); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := k.Get(secp.ClassicAddress); err == nil {
+		t.Error("read a wallet file stored under another address")
+	}
+}
+
+func TestKDFParamBounds(t *testing.T) {
+	reduceParams(t)
+	w := mustWallet(t, secpSeed)
+	for _, kdf := range []KDF{Scrypt, Argon2id} {
+		e, err := Encrypt(w, "passphrase", "", kdf)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if _, err := e.Decrypt("passphrase"); err != nil {
+			t.Fatalf("%s: %v", kdf, err)
+		}
+	}
+
+	for _, test := range []struct {
+		name   string
+		kdf    KDF
+		params KDFParams
+	}{
+		{"scrypt N not a power of two", Scrypt, KDFParams{N: 1000, R: 8, P: 1}},
+		{"scrypt N too large", Scrypt, KDFParams{N: 1 << 21, R: 8, P: 1}},
+		{"scrypt memory too large", Scrypt, KDFParams{N: 1 << 20, R: 16, P: 1}},
+		{"scrypt R zero", Scrypt, KDFParams{N: 1 << 4, P: 1}},
+		{"scrypt P too large", Scrypt, KDFParams{N: 1 << 4, R: 8, P: 17}},
+		{"argon2id time too large", Argon2id, KDFParams{Time: 17, Memory: 64, Threads: 1}},
+		{"argon2id memory too large", Argon2id, KDFParams{Time: 1, Memory: 1<<20 + 1, Threads: 1}},
+		{"argon2id no threads", Argon2id, KDFParams{Time: 1, Memory: 64}},
+		{"unknown KDF", "pbkdf2", KDFParams{N: 1 << 4, R: 8, P: 1}},
+	} {
+		e, err := Encrypt(w, "passphrase", "", Scrypt)
+		if err != nil {
+			t.Fatal(err)
+		}
+		e.Crypto.KDF = test.kdf
+		test.params.Salt = e.Crypto.KDFParams.Salt
+		e.Crypto.KDFParams = test.params
+		if _, err := e.Decrypt("passphrase"); err == nil || errors.Is(err, ErrWrongPassphrase) {
+			t.Errorf("%s: got %v, want parameters rejected", test.name, err)
+		}
+	}
+
+	if _, err := Encrypt(w, "passphrase", "", "pbkdf2"); err == nil {
+		t.Error("encrypted with an unknown KDF")
+	}
+}
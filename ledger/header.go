@@ -0,0 +1,154 @@
+// Package ledger verifies ledger headers and the transaction and state trees
+// they commit to, so that ledger data received from a server can be checked
+// independently of it.
+package ledger
+
+import (
+	"encoding/binary"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/xrpscan/xrpl-go/binarycodec"
+)
+
+// HeaderSize is the length of a serialized ledger header, without its hash.
+const HeaderSize = 118
+
+// Header is a ledger header, as returned in the ledger field of a ledger
+// response or serialized in ledger_data when binary is set.
+// https://xrpl.org/docs/references/protocol/ledger-data/ledger-header
+type Header struct {
+	LedgerIndex         uint32 `json:"ledger_index"`
+	TotalCoins          uint64 `json:"total_coins"`
+	ParentHash          string `json:"parent_hash"`
+	TransactionHash     string `json:"transaction_hash"`
+	AccountHash         string `json:"account_hash"`
+	ParentCloseTime     uint32 `json:"parent_close_time"`
+	CloseTime           uint32 `json:"close_time"`
+	CloseTimeResolution uint8  `json:"close_time_resolution"`
+	CloseFlags          uint8  `json:"close_flags"`
+	LedgerHash          string `json:"ledger_hash,omitempty"`
+}
+
+// UnmarshalJSON accepts ledger_index and total_coins as numbers or as the
+// strings returned by API version 1.
+func (h *Header) UnmarshalJSON(data []byte) error {
+	type header Header
+	var raw struct {
+		header
+		LedgerIndex json.Number `json:"ledger_index"`
+		TotalCoins  json.Number `json:"total_coins"`
+	}
+	if err := json.Unmarshal(data, &raw); err != nil {
+		return err
+	}
+	*h = Header(raw.header)
+	if raw.LedgerIndex != "" {
+		n, err := strconv.ParseUint(raw.LedgerIndex.String(), 10, 32)
+		if err != nil {
+			return fmt.Errorf("invalid ledger_index: %s", raw.LedgerIndex)
+		}
+		h.LedgerIndex = uint32(n)
+	}
+	if raw.TotalCoins != "" {
+		n, err := strconv.ParseUint(raw.TotalCoins.String(), 10, 64)
+		if err != nil {
+			return fmt.Errorf("invalid total_coins: %s", raw.TotalCoins)
+		}
+		h.TotalCoins = n
+	}
+	return nil
+}
+
+// DecodeHeader decodes a hex encoded ledger header. A 32 byte ledger hash
+// following the header, as sent by the gRPC API, is stored in LedgerHash.
+func DecodeHeader(data string) (Header, error) {
+	b, err := hex.DecodeString(data)
+	if err != nil {
+		return Header{}, err
+	}
+	if len(b) != HeaderSize && len(b) != HeaderSize+32 {
+		return Header{}, fmt.Errorf("invalid ledger header length: %d", len(b))
+	}
+	h := Header{
+		LedgerIndex:         binary.BigEndian.Uint32(b[0:4]),
+		TotalCoins:          binary.BigEndian.Uint64(b[4:12]),
+		ParentHash:          hexUpper(b[12:44]),
+		TransactionHash:     hexUpper(b[44:76]),
+		AccountHash:         hexUpper(b[76:108]),
+		ParentCloseTime:     binary.BigEndian.Uint32(b[108:112]),
+		CloseTime:           binary.BigEndian.Uint32(b[112:116]),
+		CloseTimeResolution: b[116],
+		CloseFlags:          b[117],
+	}
+	if len(b) > HeaderSize {
+		h.LedgerHash = hexUpper(b[HeaderSize:])
+	}
+	return h, nil
+}
+
+// Encode serializes the header, without its hash.
+func (h Header) Encode() ([]byte, error) {
+	b := make([]byte, 0, HeaderSize)
+	b = binary.BigEndian.AppendUint32(b, h.LedgerIndex)
+	b = binary.BigEndian.AppendUint64(b, h.TotalCoins)
+	for _, hash := range []string{h.ParentHash, h.TransactionHash, h.AccountHash} {
+		decoded, err := hex.DecodeString(hash)
+		if err != nil || len(decoded) != 32 {
+			return nil, fmt.Errorf("invalid hash in ledger header: %q", hash)
+		}
+		b = append(b, decoded...)
+	}
+	b = binary.BigEndian.AppendUint32(b, h.ParentCloseTime)
+	b = binary.BigEndian.AppendUint32(b, h.CloseTime)
+	return append(b, h.CloseTimeResolution, h.CloseFlags), nil
+}
+
+// Hash computes the ledger hash: the SHA-512Half of the serialized header
+// with the LWR hash prefix.
+func (h Header) Hash() (string, error) {
+	b, err := h.Encode()
+	if err != nil {
+		return "", err
+	}
+	return hexUpper(binarycodec.Sha512Half(binarycodec.HashPrefixLedgerMaster, b)), nil
+}
+
+// Verify checks that LedgerHash matches the hash of the header.
+func (h Header) Verify() error {
+	if h.LedgerHash == "" {
+		return errors.New("ledger header has no hash")
+	}
+	hash, err := h.Hash()
+	if err != nil {
+		return err
+	}
+	if !strings.EqualFold(hash, h.LedgerHash) {
+		return fmt.Errorf("ledger %d hash mismatch: computed %s, got %s", h.LedgerIndex, hash, h.LedgerHash)
+	}
+	return nil
+}
+
+// VerifyChain checks that child directly follows parent: its sequence is
+// one higher and its ParentHash is the hash of parent.
+func VerifyChain(parent Header, child Header) error {
+	if child.LedgerIndex != parent.LedgerIndex+1 {
+		return fmt.Errorf("ledger %d does not follow ledger %d", child.LedgerIndex, parent.LedgerIndex)
+	}
+	hash, err := parent.Hash()
+	if err != nil {
+		return err
+	}
+	if !strings.EqualFold(hash, child.ParentHash) {
+		return fmt.Errorf("ledger %d parent hash %s does not match ledger %d hash %s", child.LedgerIndex, child.ParentHash, parent.LedgerIndex, hash)
+	}
+	return nil
+}
+
+func hexUpper(b []byte) string {
+	return strings.ToUpper(hex.EncodeToString(b))
+}
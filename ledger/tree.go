@@ -0,0 +1,97 @@
+package ledger
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/xrpscan/xrpl-go/models"
+	"github.com/xrpscan/xrpl-go/shamap"
+)
+
+// Ledger is the ledger field of a ledger response requested with
+// transactions, expand and binary set.
+type Ledger struct {
+	LedgerData   string                     `json:"ledger_data"`
+	Closed       bool                       `json:"closed"`
+	Transactions []models.BinaryTransaction `json:"transactions"`
+}
+
+// Header decodes the ledger header.
+func (l Ledger) Header() (Header, error) {
+	return DecodeHeader(l.LedgerData)
+}
+
+// Verify checks that the transactions are exactly those the header commits
+// to. If ledgerHash is not empty, the header hash is checked against it too.
+func (l Ledger) Verify(ledgerHash string) error {
+	h, err := l.Header()
+	if err != nil {
+		return err
+	}
+	if ledgerHash != "" {
+		h.LedgerHash = ledgerHash
+		if err := h.Verify(); err != nil {
+			return err
+		}
+	}
+	return h.VerifyTransactions(l.Transactions)
+}
+
+// TransactionTree builds the transaction tree of a ledger.
+func TransactionTree(txs []models.BinaryTransaction) (*shamap.SHAMap, error) {
+	tree := shamap.New(shamap.TransactionNode)
+	for _, tx := range txs {
+		if err := tree.AddTransaction(tx.TxBlob, tx.Meta); err != nil {
+			return nil, err
+		}
+	}
+	return tree, nil
+}
+
+// StateTree builds the state tree of a ledger from every ledger entry in
+// it, as returned by ledger_data with binary set.
+func StateTree(objects []models.BinaryLedgerObject) (*shamap.SHAMap, error) {
+	tree := shamap.New(shamap.AccountStateNode)
+	for _, obj := range objects {
+		if err := tree.AddLedgerEntry(obj.Index, obj.Data); err != nil {
+			return nil, err
+		}
+	}
+	return tree, nil
+}
+
+// VerifyTransactions checks that txs are all the transactions of the ledger
+// by comparing the root hash of their tree with TransactionHash.
+func (h Header) VerifyTransactions(txs []models.BinaryTransaction) error {
+	tree, err := TransactionTree(txs)
+	if err != nil {
+		return err
+	}
+	return compareRoot(h, "transaction", tree.Hash(), h.TransactionHash)
+}
+
+// VerifyState checks that objects are all the ledger entries of the ledger
+// by comparing the root hash of their tree with AccountHash.
+func (h Header) VerifyState(objects []models.BinaryLedgerObject) error {
+	tree, err := StateTree(objects)
+	if err != nil {
+		return err
+	}
+	return compareRoot(h, "account", tree.Hash(), h.AccountHash)
+}
+
+// VerifyProof checks that a transaction or ledger entry proof leads to the
+// matching root hash of the header.
+func (h Header) VerifyProof(p *shamap.Proof) error {
+	if p.Type == shamap.TransactionNode {
+		return p.Verify(h.TransactionHash)
+	}
+	return p.Verify(h.AccountHash)
+}
+
+func compareRoot(h Header, tree string, computed string, expected string) error {
+	if !strings.EqualFold(computed, expected) {
+		return fmt.Errorf("ledger %d %s_hash mismatch: computed %s, got %s", h.LedgerIndex, tree, computed, expected)
+	}
+	return nil
+}
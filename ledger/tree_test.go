@@ -0,0 +1,193 @@
+package ledger
+
+import (
+	"encoding/binary"
+	"encoding/hex"
+	"encoding/json"
+	"os"
+	"testing"
+
+	"github.com/xrpscan/xrpl-go/binarycodec"
+	"github.com/xrpscan/xrpl-go/models"
+)
+
+// loadTransactions reads every transaction of mainnet ledgers 3380157 to
+// 3380160 from testdata/transaction-nodes-3380157.json: node store records,
+// with the hashes they are stored under, from github.com/rubblelabs/ripple
+// testing/testing.go (commit 6816ca31ba51). Each record is the 9 byte node
+// store prefix, the SND hash prefix, the transaction and its metadata with
+// length prefixes, and the transaction ID.
+func loadTransactions(t *testing.T) map[uint32][]models.BinaryTransaction {
+	t.Helper()
+	data, err := os.ReadFile("../testdata/transaction-nodes-3380157.json")
+	if err != nil {
+		t.Fatal(err)
+	}
+	var records []struct {
+		Hash string `json:"hash"`
+		Node string `json:"node"`
+	}
+	if err := json.Unmarshal(data, &records); err != nil {
+		t.Fatal(err)
+	}
+
+	txs := make(map[uint32][]models.BinaryTransaction)
+	for _, r := range records {
+		node, err := hex.DecodeString(r.Node)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if hash := hexUpper(binarycodec.Sha512Half(node[9:])); hash != r.Hash {
+			t.Fatalf("node %s hashes to %s", r.Hash, hash)
+		}
+		tx, rest, err := binarycodec.DecodeVL(node[13:])
+		if err != nil {
+			t.Fatal(err)
+		}
+		meta, id, err := binarycodec.DecodeVL(rest)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if hash := hexUpper(binarycodec.Sha512Half(binarycodec.HashPrefixTransactionID, tx)); hash != hexUpper(id) {
+			t.Fatalf("node %s: transaction %s stored as %X", r.Hash, hash, id)
+		}
+		sequence := binary.BigEndian.Uint32(node)
+		txs[sequence] = append(txs[sequence], models.BinaryTransaction{TxBlob: hexUpper(tx), Meta: hexUpper(meta)})
+	}
+	return txs
+}
+
+func TestVerifyTransactions(t *testing.T) {
+	txs := loadTransactions(t)
+	headers := decodeConsecutive(t)
+	for i, h := range headers {
+		ledgerTxs := txs[h.LedgerIndex]
+		if len(ledgerTxs) < 2 {
+			t.Fatalf("ledger %d has %d transactions", h.LedgerIndex, len(ledgerTxs))
+		}
+		if err := h.VerifyTransactions(ledgerTxs); err != nil {
+			t.Error(err)
+		}
+		// The tree does not depend on the order of the transactions.
+		reversed := make([]models.BinaryTransaction, len(ledgerTxs))
+		for j, tx := range ledgerTxs {
+			reversed[len(ledgerTxs)-1-j] = tx
+		}
+		if err := h.VerifyTransactions(reversed); err != nil {
+			t.Error(err)
+		}
+
+		if err := h.VerifyTransactions(ledgerTxs[1:]); err == nil {
+			t.Errorf("ledger %d: verified with a transaction missing", h.LedgerIndex)
+		}
+		changed := append([]models.BinaryTransaction{}, ledgerTxs...)
+		changed[0].Meta = flipHex(changed[0].Meta)
+		if err := h.VerifyTransactions(changed); err == nil {
+			t.Errorf("ledger %d: verified with changed metadata", h.LedgerIndex)
+		}
+		other := txs[headers[(i+1)%len(headers)].LedgerIndex][0]
+		if err := h.VerifyTransactions(append(append([]models.BinaryTransaction{}, ledgerTxs...), other)); err == nil {
+			t.Errorf("ledger %d: verified with a transaction of another ledger", h.LedgerIndex)
+		}
+	}
+}
+
+func TestLedgerVerify(t *testing.T) {
+	// A ledger response with transactions, expand and binary set.
+	txs := loadTransactions(t)
+	headers := decodeConsecutive(t)
+	h := headers[0]
+	header, err := h.Encode()
+	if err != nil {
+		t.Fatal(err)
+	}
+	data, err := json.Marshal(map[string]interface{}{
+		"ledger_data":  hexUpper(header),
+		"closed":       true,
+		"transactions": txs[h.LedgerIndex],
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	var l Ledger
+	if err := json.Unmarshal(data, &l); err != nil {
+		t.Fatal(err)
+	}
+	if err := l.Verify(h.LedgerHash); err != nil {
+		t.Error(err)
+	}
+	if err := l.Verify(""); err != nil {
+		t.Error(err)
+	}
+	if err := l.Verify(headers[1].LedgerHash); err == nil {
+		t.Error("verified against the hash of another ledger")
+	}
+	l.Transactions = txs[headers[1].LedgerIndex]
+	if err := l.Verify(h.LedgerHash); err == nil {
+		t.Error("verified with the transactions of another ledger")
+	}
+
+	// Ledger 38129 in JSON form, its transaction encoded by the codec.
+	full := loadHeader(t)
+	var expanded struct {
+		Transactions []map[string]interface{} `json:"transactions"`
+	}
+	data, err = os.ReadFile("../testdata/ledger-full-38129.json")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := json.Unmarshal(data, &expanded); err != nil {
+		t.Fatal(err)
+	}
+	var encoded []models.BinaryTransaction
+	for _, tx := range expanded.Transactions {
+		meta := tx["metaData"].(map[string]interface{})
+		fields := make(map[string]interface{})
+		for k, v := range tx {
+			if k != "hash" && k != "metaData" {
+				fields[k] = v
+			}
+		}
+		txBlob, err := binarycodec.Encode(fields)
+		if err != nil {
+			t.Fatal(err)
+		}
+		metaBlob, err := binarycodec.Encode(meta)
+		if err != nil {
+			t.Fatal(err)
+		}
+		encoded = append(encoded, models.BinaryTransaction{TxBlob: txBlob, Meta: metaBlob})
+	}
+	if err := full.VerifyTransactions(encoded); err != nil {
+		t.Error(err)
+	}
+}
+
+func TestVerifyState(t *testing.T) {
+	// The complete state of ledger 38129, encoded by the codec.
+	h := loadHeader(t)
+	var state struct {
+		AccountState []map[string]interface{} `json:"accountState"`
+	}
+	data, err := os.ReadFile("../testdata/ledger-full-38129.json")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := json.Unmarshal(data, &state); err != nil {
+		t.Fatal(err)
+	}
+	objects := make([]models.BinaryLedgerObject, len(state.AccountState))
+	for i, entry := range state.AccountState {
+		encoded, err := binarycodec.Encode(entry)
+		if err != nil {
+			t.Fatal(err)
+		}
+		objects[i] = models.BinaryLedgerObject{Index: entry["index"].(string), Data: encoded}
+	}
+	if err := h.VerifyState(objects); err != nil {
+		t.Error(err)
+	}
+	if err := h.VerifyState(objects[1:]); err == nil {
+		t.Error("verified with a ledger entry missing")
+	}
+}
@@ -1,6 +1,11 @@
 package methods
 
-import "github.com/xrpscan/xrpl-go/models"
+import (
+	"errors"
+
+	"github.com/xrpscan/xrpl-go/addresscodec"
+	"github.com/xrpscan/xrpl-go/models"
+)
 
 type AccountInfoRequest struct {
 	models.BaseRequest
@@ -12,6 +17,14 @@ type AccountInfoRequest struct {
 	Strict      bool   `json:"strict,omitempty"`
 }
 
+// Validate checks that Account is a valid classic address.
+func (r AccountInfoRequest) Validate() error {
+	if !addresscodec.IsValidClassicAddress(r.Account) {
+		return errors.New("invalid account address: " + r.Account)
+	}
+	return nil
+}
+
 type QueueTransaction struct {
 	AuthChange    bool   `json:"auth_change,omitempty"`
 	Fee           string `json:"fee,omitempty"`
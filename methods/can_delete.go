@@ -0,0 +1,23 @@
+package methods
+
+import "github.com/xrpscan/xrpl-go/models"
+
+// The can_delete method informs the rippled server of the latest ledger which
+// may be deleted when using online deletion with advisory_delete enabled.
+// CanDelete is a ledger index, a ledger hash, or one of "never", "always" and
+// "now". Leave it empty to query the current setting. Expects a response in
+// the form of a CanDeleteResponse.
+type CanDeleteRequest struct {
+	models.BaseRequest
+	CanDelete interface{} `json:"can_delete,omitempty"`
+}
+
+// Response expected from a CanDeleteRequest.
+type CanDeleteResponse struct {
+	models.BaseResponse
+	Result CanDeleteResult `json:"result,omitempty"`
+}
+
+type CanDeleteResult struct {
+	CanDelete uint64 `json:"can_delete,omitempty"`
+}
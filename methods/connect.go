@@ -0,0 +1,23 @@
+package methods
+
+import "github.com/xrpscan/xrpl-go/models"
+
+// The connect command forces the rippled server to connect to a specific
+// peer rippled server. Expects a response in the form of a ConnectResponse.
+type ConnectRequest struct {
+	models.BaseRequest
+	Ip   string `json:"ip,omitempty"`
+	Port int    `json:"port,omitempty"`
+}
+
+// Response expected from a ConnectRequest.
+type ConnectResponse struct {
+	models.BaseResponse
+	Result MessageResult `json:"result,omitempty"`
+}
+
+// MessageResult is the result of admin methods that only acknowledge the
+// command with a message.
+type MessageResult struct {
+	Message string `json:"message,omitempty"`
+}
@@ -0,0 +1,21 @@
+package methods
+
+import "github.com/xrpscan/xrpl-go/models"
+
+// The consensus_info command provides information about the consensus
+// process for debugging purposes. Expects a response in the form of a
+// ConsensusInfoResponse.
+type ConsensusInfoRequest struct {
+	models.BaseRequest
+}
+
+// Response expected from a ConsensusInfoRequest.
+type ConsensusInfoResponse struct {
+	models.BaseResponse
+	Result ConsensusInfoResult `json:"result,omitempty"`
+}
+
+// The contents of Info are subject to change without notice.
+type ConsensusInfoResult struct {
+	Info map[string]interface{} `json:"info,omitempty"`
+}
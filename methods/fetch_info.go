@@ -0,0 +1,32 @@
+package methods
+
+import "github.com/xrpscan/xrpl-go/models"
+
+// The fetch_info command returns information about objects that this server
+// is currently fetching from the network, and how many peers have that
+// information. Set Clear to reset current fetches. Expects a response in the
+// form of a FetchInfoResponse.
+type FetchInfoRequest struct {
+	models.BaseRequest
+	Clear bool `json:"clear,omitempty"`
+}
+
+// Response expected from a FetchInfoRequest.
+type FetchInfoResponse struct {
+	models.BaseResponse
+	Result FetchInfoResult `json:"result,omitempty"`
+}
+
+type FetchInfoResult struct {
+	Info map[string]FetchInfo `json:"info,omitempty"`
+}
+
+type FetchInfo struct {
+	Hash              string   `json:"hash,omitempty"`
+	HaveHeader        bool     `json:"have_header,omitempty"`
+	HaveState         bool     `json:"have_state,omitempty"`
+	HaveTransactions  bool     `json:"have_transactions,omitempty"`
+	NeededStateHashes []string `json:"needed_state_hashes,omitempty"`
+	Peers             int64    `json:"peers,omitempty"`
+	Timeouts          int64    `json:"timeouts,omitempty"`
+}
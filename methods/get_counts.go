@@ -0,0 +1,22 @@
+package methods
+
+import "github.com/xrpscan/xrpl-go/models"
+
+// The get_counts command provides various stats about the health of the
+// server, mostly the number of objects of different types that it currently
+// holds in memory. Only object types with at least MinCount objects are
+// listed. Expects a response in the form of a GetCountsResponse.
+type GetCountsRequest struct {
+	models.BaseRequest
+	MinCount int64 `json:"min_count,omitempty"`
+}
+
+// Response expected from a GetCountsRequest.
+type GetCountsResponse struct {
+	models.BaseResponse
+	Result GetCountsResult `json:"result,omitempty"`
+}
+
+// GetCountsResult maps object type names and cache statistics to their
+// values. The set of keys depends on the server version.
+type GetCountsResult map[string]interface{}
@@ -0,0 +1,22 @@
+package methods
+
+import "github.com/xrpscan/xrpl-go/models"
+
+// The ledger_accept method forces the server to close the current-working
+// ledger and move to the next ledger number. This method is intended for
+// testing purposes only, and is only available when the rippled server is
+// running stand-alone mode. Expects a response in the form of a
+// LedgerAcceptResponse.
+type LedgerAcceptRequest struct {
+	models.BaseRequest
+}
+
+// Response expected from a LedgerAcceptRequest.
+type LedgerAcceptResponse struct {
+	models.BaseResponse
+	Result LedgerAcceptResult `json:"result,omitempty"`
+}
+
+type LedgerAcceptResult struct {
+	LedgerCurrentIndex uint64 `json:"ledger_current_index,omitempty"`
+}
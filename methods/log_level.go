@@ -0,0 +1,23 @@
+package methods
+
+import "github.com/xrpscan/xrpl-go/models"
+
+// The log_level command changes the rippled server's logging verbosity, or
+// returns the current logging level for each category (called a partition)
+// of log messages when Severity is empty. Expects a response in the form of
+// a LogLevelResponse.
+type LogLevelRequest struct {
+	models.BaseRequest
+	Severity  string `json:"severity,omitempty"`
+	Partition string `json:"partition,omitempty"`
+}
+
+// Response expected from a LogLevelRequest.
+type LogLevelResponse struct {
+	models.BaseResponse
+	Result LogLevelResult `json:"result,omitempty"`
+}
+
+type LogLevelResult struct {
+	Levels map[string]string `json:"levels,omitempty"`
+}
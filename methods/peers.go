@@ -0,0 +1,47 @@
+package methods
+
+import "github.com/xrpscan/xrpl-go/models"
+
+// The peers method returns a list of all other rippled servers currently
+// connected to this one over the Peer Protocol, including information on
+// their connection and sync status. Expects a response in the form of a
+// PeersResponse.
+type PeersRequest struct {
+	models.BaseRequest
+}
+
+// Response expected from a PeersRequest.
+type PeersResponse struct {
+	models.BaseResponse
+	Result PeersResult `json:"result,omitempty"`
+}
+
+type PeersResult struct {
+	Cluster map[string]ClusterNode `json:"cluster,omitempty"`
+	Peers   []Peer                 `json:"peers,omitempty"`
+}
+
+type ClusterNode struct {
+	Tag  string `json:"tag,omitempty"`
+	Fee  int64  `json:"fee,omitempty"`
+	Age  int64  `json:"age,omitempty"`
+	Name string `json:"name,omitempty"`
+}
+
+type Peer struct {
+	Address         string `json:"address,omitempty"`
+	Cluster         bool   `json:"cluster,omitempty"`
+	Name            string `json:"name,omitempty"`
+	CompleteLedgers string `json:"complete_ledgers,omitempty"`
+	Inbound         bool   `json:"inbound,omitempty"`
+	Latency         int64  `json:"latency,omitempty"`
+	Ledger          string `json:"ledger,omitempty"`
+	Load            int64  `json:"load,omitempty"`
+	Protocol        string `json:"protocol,omitempty"`
+	PublicKey       string `json:"public_key,omitempty"`
+	Reserved        bool   `json:"reserved,omitempty"`
+	Sanity          string `json:"sanity,omitempty"`
+	Status          string `json:"status,omitempty"`
+	Uptime          int64  `json:"uptime,omitempty"`
+	Version         string `json:"version,omitempty"`
+}
@@ -0,0 +1,23 @@
+package methods
+
+import (
+	"encoding/json"
+
+	"github.com/xrpscan/xrpl-go/models"
+)
+
+// The server_definitions method returns the field, type and enum tables the
+// server uses for binary serialization, in definitions.json format. If Hash
+// matches the server's current definitions, only the hash is returned.
+// Expects a response in the form of a ServerDefinitionsResponse.
+type ServerDefinitionsRequest struct {
+	models.BaseRequest
+	Hash string `json:"hash,omitempty"`
+}
+
+// Response expected from a ServerDefinitionsRequest. Result is kept raw so
+// that it can be passed to binarycodec.LoadDefinitions.
+type ServerDefinitionsResponse struct {
+	models.BaseResponse
+	Result json.RawMessage `json:"result,omitempty"`
+}
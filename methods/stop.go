@@ -0,0 +1,15 @@
+package methods
+
+import "github.com/xrpscan/xrpl-go/models"
+
+// Gracefully shuts down the server. Expects a response in the form of a
+// StopResponse.
+type StopRequest struct {
+	models.BaseRequest
+}
+
+// Response expected from a StopRequest.
+type StopResponse struct {
+	models.BaseResponse
+	Result MessageResult `json:"result,omitempty"`
+}
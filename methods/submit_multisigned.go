@@ -0,0 +1,26 @@
+package methods
+
+import "github.com/xrpscan/xrpl-go/models"
+
+// The submit_multisigned command applies a multi-signed transaction and
+// sends it to the network to be included in future ledgers. Expects a
+// response in the form of a SubmitMultisignedResponse.
+type SubmitMultisignedRequest struct {
+	models.BaseRequest
+	TxJson   map[string]interface{} `json:"tx_json,omitempty"`
+	FailHard bool                   `json:"fail_hard,omitempty"`
+}
+
+// Response expected from a SubmitMultisignedRequest.
+type SubmitMultisignedResponse struct {
+	models.BaseResponse
+	Result SubmitMultisignedResult `json:"result,omitempty"`
+}
+
+type SubmitMultisignedResult struct {
+	EngineResult        string                 `json:"engine_result,omitempty"`
+	EngineResultCode    int                    `json:"engine_result_code,omitempty"`
+	EngineResultMessage string                 `json:"engine_result_message,omitempty"`
+	TxBlob              string                 `json:"tx_blob,omitempty"`
+	TxJson              map[string]interface{} `json:"tx_json,omitempty"`
+}
@@ -1,6 +1,11 @@
 package methods
 
-import "github.com/xrpscan/xrpl-go/models"
+import (
+	"bytes"
+	"encoding/json"
+
+	"github.com/xrpscan/xrpl-go/models"
+)
 
 // The tx method retrieves information on a single transaction, by its
 // identifying hash. Expects a response in the form of a TxResponse.
@@ -19,11 +24,113 @@ type TxResponse struct {
 	SearchedAll bool             `json:"searched_all,omitempty"`
 }
 
+// TxResponseResult is the result of a tx request. Transaction holds the
+// concrete type of the transaction, e.g. *models.TransactionPayment. Both
+// the API v1 form, where the transaction fields are inline, and the API v2
+// form, where they are in tx_json, are decoded.
+//
+// Binary responses set TxBlob and MetaBlob instead of Transaction and Meta.
+// Decode them with binarycodec, using the definitions of the network the
+// transaction is from.
 type TxResponseResult struct {
-	models.Transaction
+	Transaction models.Transaction         `json:"-"`
+	TxBlob      string                     `json:"-"`
+	MetaBlob    string                     `json:"-"`
 	Hash        string                     `json:"hash,omitempty"`
 	LedgerIndex int64                      `json:"ledger_index,omitempty"`
-	Meta        models.TransactionMetadata `json:"meta,omitempty"`
+	Meta        models.TransactionMetadata `json:"-"`
 	Validated   bool                       `json:"validated,omitempty"`
 	Date        int64                      `json:"date,omitempty"`
 }
+
+// UnmarshalJSON decodes the result and its transaction.
+func (r *TxResponseResult) UnmarshalJSON(data []byte) error {
+	type result TxResponseResult
+	var raw struct {
+		result
+		Tx              json.RawMessage `json:"tx,omitempty"`
+		TxJson          json.RawMessage `json:"tx_json,omitempty"`
+		TxBlob          string          `json:"tx_blob,omitempty"`
+		Meta            json.RawMessage `json:"meta,omitempty"`
+		MetaBlob        string          `json:"meta_blob,omitempty"`
+		TransactionType string          `json:"TransactionType,omitempty"`
+	}
+	if err := json.Unmarshal(data, &raw); err != nil {
+		return err
+	}
+	*r = TxResponseResult(raw.result)
+
+	// API v1 binary responses carry hex strings in tx and meta.
+	r.TxBlob, r.MetaBlob = raw.TxBlob, raw.MetaBlob
+	if err := decodeBlob(raw.Tx, &r.TxBlob); err != nil {
+		return err
+	}
+	if isJSONString(raw.Meta) {
+		if err := json.Unmarshal(raw.Meta, &r.MetaBlob); err != nil {
+			return err
+		}
+	} else if raw.Meta != nil {
+		if err := json.Unmarshal(raw.Meta, &r.Meta); err != nil {
+			return err
+		}
+	}
+
+	txJSON := raw.TxJson
+	if txJSON == nil && raw.TransactionType != "" {
+		txJSON = data
+	}
+	if txJSON == nil {
+		return nil
+	}
+	tx, err := models.UnmarshalTransaction(txJSON)
+	if err != nil {
+		return err
+	}
+	r.Transaction = tx
+	return nil
+}
+
+// MarshalJSON encodes the result in the API v1 form, with the transaction
+// fields inline.
+func (r TxResponseResult) MarshalJSON() ([]byte, error) {
+	type result TxResponseResult
+	data, err := json.Marshal(result(r))
+	if err != nil {
+		return nil, err
+	}
+	fields := make(map[string]json.RawMessage)
+	if err := json.Unmarshal(data, &fields); err != nil {
+		return nil, err
+	}
+	if r.TxBlob != "" {
+		fields["tx"], _ = json.Marshal(r.TxBlob)
+	}
+	if r.MetaBlob != "" {
+		fields["meta"], _ = json.Marshal(r.MetaBlob)
+	} else if fields["meta"], err = json.Marshal(r.Meta); err != nil {
+		return nil, err
+	}
+	if r.Transaction != nil {
+		txJSON, err := models.MarshalTransaction(r.Transaction)
+		if err != nil {
+			return nil, err
+		}
+		if err := json.Unmarshal(txJSON, &fields); err != nil {
+			return nil, err
+		}
+	}
+	return json.Marshal(fields)
+}
+
+// decodeBlob sets blob from a JSON string. Other JSON values are ignored.
+func decodeBlob(data json.RawMessage, blob *string) error {
+	if !isJSONString(data) {
+		return nil
+	}
+	return json.Unmarshal(data, blob)
+}
+
+func isJSONString(data json.RawMessage) bool {
+	data = bytes.TrimSpace(data)
+	return len(data) > 0 && data[0] == '"'
+}
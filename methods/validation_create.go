@@ -0,0 +1,24 @@
+package methods
+
+import "github.com/xrpscan/xrpl-go/models"
+
+// Use the validation_create method to generate cryptographic keys a rippled
+// server can use to identify itself to the network. If Secret is empty, a
+// random seed is used. Expects a response in the form of a
+// ValidationCreateResponse.
+type ValidationCreateRequest struct {
+	models.BaseRequest
+	Secret string `json:"secret,omitempty"`
+}
+
+// Response expected from a ValidationCreateRequest.
+type ValidationCreateResponse struct {
+	models.BaseResponse
+	Result ValidationCreateResult `json:"result,omitempty"`
+}
+
+type ValidationCreateResult struct {
+	ValidationKey       string `json:"validation_key,omitempty"`
+	ValidationPublicKey string `json:"validation_public_key,omitempty"`
+	ValidationSeed      string `json:"validation_seed,omitempty"`
+}
@@ -0,0 +1,32 @@
+package methods
+
+import "github.com/xrpscan/xrpl-go/models"
+
+// Use the wallet_propose method to generate a key pair and XRP Ledger
+// address. At most one of Passphrase, Seed and SeedHex may be set; if none
+// is, a random seed is generated. Expects a response in the form of a
+// WalletProposeResponse.
+type WalletProposeRequest struct {
+	models.BaseRequest
+	KeyType    string `json:"key_type,omitempty"`
+	Passphrase string `json:"passphrase,omitempty"`
+	Seed       string `json:"seed,omitempty"`
+	SeedHex    string `json:"seed_hex,omitempty"`
+}
+
+// Response expected from a WalletProposeRequest.
+type WalletProposeResponse struct {
+	models.BaseResponse
+	Result WalletProposeResult `json:"result,omitempty"`
+}
+
+type WalletProposeResult struct {
+	AccountId     string `json:"account_id,omitempty"`
+	KeyType       string `json:"key_type,omitempty"`
+	MasterKey     string `json:"master_key,omitempty"`
+	MasterSeed    string `json:"master_seed,omitempty"`
+	MasterSeedHex string `json:"master_seed_hex,omitempty"`
+	PublicKey     string `json:"public_key,omitempty"`
+	PublicKeyHex  string `json:"public_key_hex,omitempty"`
+	Warning       string `json:"warning,omitempty"`
+}
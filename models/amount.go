@@ -0,0 +1,96 @@
+package models
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+)
+
+// issuedCurrencyAmountJSON is the JSON form of an issued currency amount.
+type issuedCurrencyAmountJSON struct {
+	Currency      string `json:"currency"`
+	Issuer        string `json:"issuer,omitempty"`
+	Value         string `json:"value"`
+	MPTIssuanceID string `json:"mpt_issuance_id,omitempty"`
+}
+
+// mptAmountJSON is the JSON form of an MPT amount.
+type mptAmountJSON struct {
+	MPTIssuanceID string `json:"mpt_issuance_id"`
+	Value         string `json:"value"`
+}
+
+// MarshalJSON encodes the amount as an object with currency, issuer and
+// value, or with mpt_issuance_id and value for MPT amounts.
+func (a IssuedCurrencyAmount) MarshalJSON() ([]byte, error) {
+	if a.MPTIssuanceID != "" {
+		return json.Marshal(mptAmountJSON{
+			MPTIssuanceID: a.MPTIssuanceID,
+			Value:         a.Value,
+		})
+	}
+	return json.Marshal(issuedCurrencyAmountJSON{
+		Currency: a.Currency.Currency,
+		Issuer:   a.Issuer,
+		Value:    a.Value,
+	})
+}
+
+// UnmarshalJSON decodes an amount object with currency, issuer and value,
+// or with mpt_issuance_id and value.
+func (a *IssuedCurrencyAmount) UnmarshalJSON(data []byte) error {
+	var raw issuedCurrencyAmountJSON
+	if err := json.Unmarshal(data, &raw); err != nil {
+		return err
+	}
+	if raw.MPTIssuanceID != "" && (raw.Currency != "" || raw.Issuer != "") {
+		return errors.New("amount has both a currency and an MPT issuance")
+	}
+	*a = IssuedCurrencyAmount{
+		IssuedCurrency: IssuedCurrency{
+			Currency: Currency{Currency: raw.Currency},
+			Issuer:   raw.Issuer,
+		},
+		Value:         raw.Value,
+		MPTIssuanceID: raw.MPTIssuanceID,
+	}
+	return nil
+}
+
+// IsXRP reports whether the amount is XRP, in which case Value is a number
+// of drops.
+func (a Amount) IsXRP() bool {
+	return a.Currency.Currency == "" && a.Issuer == "" && a.MPTIssuanceID == ""
+}
+
+// IsMPT reports whether the amount is of a multi-purpose token, in which
+// case Value is an integer number of units.
+func (a Amount) IsMPT() bool {
+	return a.MPTIssuanceID != ""
+}
+
+// MarshalJSON encodes XRP amounts as a string of drops and issued currency
+// amounts as an object.
+// https://xrpl.org/docs/references/protocol/data-types/basic-data-types#specifying-currency-amounts
+func (a Amount) MarshalJSON() ([]byte, error) {
+	if a.IsXRP() {
+		return json.Marshal(a.Value)
+	}
+	return IssuedCurrencyAmount(a).MarshalJSON()
+}
+
+// UnmarshalJSON decodes a string of drops or an issued currency amount
+// object.
+func (a *Amount) UnmarshalJSON(data []byte) error {
+	data = bytes.TrimSpace(data)
+	if len(data) > 0 && data[0] == '"' {
+		*a = Amount{}
+		return json.Unmarshal(data, &a.Value)
+	}
+	var amount IssuedCurrencyAmount
+	if err := amount.UnmarshalJSON(data); err != nil {
+		return err
+	}
+	*a = Amount(amount)
+	return nil
+}
@@ -11,31 +11,34 @@ type IssuedCurrency struct {
 	Issuer   string `json:"issuer,omitempty"`
 }
 
+// IssuedCurrencyAmount is an amount of an issued currency or, when
+// MPTIssuanceID is set, of a multi-purpose token.
 type IssuedCurrencyAmount struct {
 	IssuedCurrency `json:"issued_currency,omitempty"`
 	Value          string `json:"value,omitempty"`
+	MPTIssuanceID  string `json:"mpt_issuance_id,omitempty"`
 }
 
 type Amount IssuedCurrencyAmount
 
 type Signer struct {
-	Signer SignerMap `json:"signer,omitempty"`
+	Signer SignerMap `json:"Signer"`
 }
 
 type SignerMap struct {
-	Account       string `json:"account,omitempty"`
-	TxnSignature  string `json:"txn_signature,omitempty"`
-	SigningPubKey string `json:"signing_pub_key,omitempty"`
+	Account       string `json:"Account,omitempty"`
+	TxnSignature  string `json:"TxnSignature,omitempty"`
+	SigningPubKey string `json:"SigningPubKey,omitempty"`
 }
 
 type Memo struct {
-	Memo MemoMap `json:"memo,omitempty"`
+	Memo MemoMap `json:"Memo"`
 }
 
 type MemoMap struct {
-	MemoData   string `json:"memo_data,omitempty"`
-	MemoType   string `json:"memo_type,omitempty"`
-	MemoFormat string `json:"memo_format,omitempty"`
+	MemoData   string `json:"MemoData,omitempty"`
+	MemoType   string `json:"MemoType,omitempty"`
+	MemoFormat string `json:"MemoFormat,omitempty"`
 }
 
 type StreamType string
@@ -49,13 +52,13 @@ type PathStep struct {
 type Path []PathStep
 
 type SignerEntry struct {
-	SignerEntry SignerEntryMap `json:"signer_entry,omitempty"`
+	SignerEntry SignerEntryMap `json:"SignerEntry"`
 }
 
 type SignerEntryMap struct {
-	Account       string `json:"account,omitempty"`
-	SignerWeight  int16  `json:"signer_weight,omitempty"`
-	WalletLocator string `json:"wallet_locator,omitempty"`
+	Account       string `json:"Account,omitempty"`
+	SignerWeight  int16  `json:"SignerWeight,omitempty"`
+	WalletLocator string `json:"WalletLocator,omitempty"`
 }
 
 type ResponseOnlyTxInfo struct {
@@ -40,6 +40,19 @@ type BinaryLedger struct {
 	Transactions []string `json:"transactions,omitempty"`
 }
 
+// Transaction and metadata blobs of a ledger requested with binary and
+// expand set.
+type BinaryTransaction struct {
+	TxBlob string `json:"tx_blob,omitempty"`
+	Meta   string `json:"meta,omitempty"`
+}
+
+// Ledger entry returned by ledger_data with binary set.
+type BinaryLedgerObject struct {
+	Data  string `json:"data,omitempty"`
+	Index string `json:"index,omitempty"`
+}
+
 type LedgerResponse struct {
 	BaseResponse
 	Result LedgerResult
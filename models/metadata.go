@@ -35,8 +35,8 @@ type DeletedNodeMap struct {
 
 type TransactionMetadata struct {
 	AffectedNodes     []map[string]interface{}
-	DeliveredAmount   Amount
-	Delivered_Amount  Amount `json:"delivered_amount,omitempty"`
+	DeliveredAmount   *Amount `json:",omitempty"`
+	Delivered_Amount  *Amount `json:"delivered_amount,omitempty"`
 	TransactionIndex  int64
 	TransactionResult string
 }
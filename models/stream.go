@@ -1,5 +1,7 @@
 package models
 
+import "encoding/json"
+
 type LedgerStream struct {
 	Type             string `json:"type,omitempty"` // default: ledgerClosed
 	FeeBase          uint64 `json:"fee_base,omitempty"`
@@ -32,6 +34,16 @@ type ValidationStream struct {
 	ValidationPublicKey string   `json:"validation_public_key,omitempty"`
 }
 
+type ManifestStream struct {
+	Type            string `json:"type,omitempty"` // default: manifestReceived
+	Domain          string `json:"domain,omitempty"`
+	MasterKey       string `json:"master_key,omitempty"`
+	MasterSignature string `json:"master_signature,omitempty"`
+	Seq             uint32 `json:"seq,omitempty"`
+	Signature       string `json:"signature,omitempty"`
+	SigningKey      string `json:"signing_key,omitempty"`
+}
+
 type TransactionStream struct {
 	Type                string `json:"type,omitempty"` // default: transaction
 	Status              string `json:"status,omitempty"`
@@ -41,11 +53,22 @@ type TransactionStream struct {
 	LedgerCurrentIndex  uint64 `json:"ledger_current_index,omitempty"`
 	LedgerHash          string `json:"ledger_hash,omitempty"`
 	LedgerIndex         uint64 `json:"ledger_index,omitempty"`
-	Meta                string `json:"meta,omitempty"`
-	Transaction         string `json:"transaction,omitempty"`
-	Validated           bool   `json:"validated,omitempty"`
-	// Meta                TransactionMeta `json:"meta,omitempty"`
-	// Transaction         Transaction     `json:"transaction,omitempty"`
+	// Meta and Transaction are JSON objects, kept raw so that they can be
+	// decoded into whichever transaction type applies. API v2 sends the
+	// transaction as tx_json instead.
+	Meta        json.RawMessage `json:"meta,omitempty"`
+	Transaction json.RawMessage `json:"transaction,omitempty"`
+	TxJson      json.RawMessage `json:"tx_json,omitempty"`
+	Validated   bool            `json:"validated,omitempty"`
+}
+
+// DecodeTransaction decodes the streamed transaction into its concrete
+// type, e.g. *TransactionPayment.
+func (s TransactionStream) DecodeTransaction() (Transaction, error) {
+	if s.TxJson != nil {
+		return UnmarshalTransaction(s.TxJson)
+	}
+	return UnmarshalTransaction(s.Transaction)
 }
 
 type PeerStatusStream struct {
@@ -1,25 +1,29 @@
 package models
 
 import (
+	"errors"
 	"fmt"
+	"strconv"
 	"strings"
 )
 
 // Set of common fields for every transaction
 type BaseTransaction struct {
-	Account            string
-	TransactionType    string
-	Fee                string
-	Sequence           int64
-	AccountTxnID       string
-	Flags              int64
-	LastLedgerSequence int64
-	Memos              []Memo
-	Signers            []Signer
-	SourceTag          int64
-	SigningPubKey      string
-	TicketSequence     int64
-	TxnSignature       string
+	Account            string   `json:",omitempty"`
+	TransactionType    string   `json:",omitempty"`
+	Fee                string   `json:",omitempty"`
+	Sequence           int64    `json:"Sequence"`
+	AccountTxnID       string   `json:",omitempty"`
+	Flags              int64    `json:",omitempty"`
+	LastLedgerSequence int64    `json:",omitempty"`
+	Memos              []Memo   `json:",omitempty"`
+	Signers            []Signer `json:",omitempty"`
+	SourceTag          int64    `json:",omitempty"`
+	SigningPubKey      string   `json:",omitempty"`
+	TicketSequence     int64    `json:",omitempty"`
+	TxnSignature       string   `json:",omitempty"`
+	NetworkID          int64    `json:",omitempty"`
+	Delegate           string   `json:",omitempty"`
 }
 
 // A Payment transaction represents a transfer of value from one account to
@@ -28,14 +32,14 @@ type BaseTransaction struct {
 // TransactionType: 'Payment'
 type TransactionPayment struct {
 	BaseTransaction
-	Amount         Amount
-	Destination    string
-	DestinationTag int64
-	InvoiceID      string
-	Paths          []Path
-	SendMax        Amount
-	DeliverMin     Amount
-	Flags          int64
+	Amount         Amount  `json:"Amount"`
+	DeliverMax     *Amount `json:",omitempty"` // replaces Amount in API v2
+	Destination    string  `json:",omitempty"`
+	DestinationTag int64   `json:",omitempty"`
+	InvoiceID      string  `json:",omitempty"`
+	Paths          []Path  `json:",omitempty"`
+	SendMax        *Amount `json:",omitempty"`
+	DeliverMin     *Amount `json:",omitempty"`
 }
 
 type PaymentFlags struct {
@@ -51,9 +55,9 @@ type PaymentFlags struct {
 // TransactionType: 'NFTokenAcceptOffer'
 type TransactionNFTokenAcceptOffer struct {
 	BaseTransaction
-	NFTokenSellOffer string
-	NFTokenBuyOffer  string
-	NFTokenBrokerFee Amount
+	NFTokenSellOffer string  `json:",omitempty"`
+	NFTokenBuyOffer  string  `json:",omitempty"`
+	NFTokenBrokerFee *Amount `json:",omitempty"`
 }
 
 // The NFTokenBurn transaction is used to remove an NFToken object from the
@@ -63,9 +67,8 @@ type TransactionNFTokenAcceptOffer struct {
 // TransactionType: 'NFTokenBurn'
 type TransactionNFTokenBurn struct {
 	BaseTransaction
-	Account   string
-	NFTokenID string
-	Owner     string
+	NFTokenID string `json:",omitempty"`
+	Owner     string `json:",omitempty"`
 }
 
 // The NFTokenCancelOffer transaction deletes existing NFTokenOffer objects.
@@ -75,7 +78,7 @@ type TransactionNFTokenBurn struct {
 // TransactionType: 'NFTokenCancelOffer'
 type TransactionNFTokenCancelOffer struct {
 	BaseTransaction
-	NFTokenOffers []string
+	NFTokenOffers []string `json:",omitempty"`
 }
 
 // The NFTokenCreateOffer transaction creates either an offer to buy an
@@ -85,12 +88,11 @@ type TransactionNFTokenCancelOffer struct {
 // TransactionType: 'NFTokenCreateOffer'
 type TransactionNFTokenCreateOffer struct {
 	BaseTransaction
-	NFTokenID   string
-	Amount      Amount
-	Owner       string
-	Expiration  int64
-	Destination string
-	Flags       int64
+	NFTokenID   string `json:",omitempty"`
+	Amount      Amount `json:"Amount"`
+	Owner       string `json:",omitempty"`
+	Expiration  int64  `json:",omitempty"`
+	Destination string `json:",omitempty"`
 }
 
 type NFTokenCreateOfferFlags struct {
@@ -106,11 +108,10 @@ type NFTokenCreateOfferFlags struct {
 // TransactionType: 'NFTokenMint'
 type TransactionNFTokenMint struct {
 	BaseTransaction
-	NFTokenTaxon int64
-	Issuer       string
-	TransferFee  int64
-	URI          string
-	Flags        int64
+	NFTokenTaxon int64  `json:"NFTokenTaxon"`
+	Issuer       string `json:",omitempty"`
+	TransferFee  int64  `json:",omitempty"`
+	URI          string `json:",omitempty"`
 }
 
 type NFTokenMintFlags struct {
@@ -128,8 +129,8 @@ type NFTokenMintFlags struct {
 // TransactionType: 'AccountDelete'
 type TransactionAccountDelete struct {
 	BaseTransaction
-	Destination    string
-	DestinationTag int64
+	Destination    string `json:",omitempty"`
+	DestinationTag int64  `json:",omitempty"`
 }
 
 // Map of flags to boolean values representing {@link AccountSet} transaction
@@ -150,15 +151,14 @@ type AccountSetFlags struct {
 // TransactionType: 'AccountSet'
 type TransactionAccountSet struct {
 	BaseTransaction
-	Flags         int64
-	ClearFlag     int64
-	Domain        string
-	EmailHash     string
-	MessageKey    string
-	SetFlag       int64
-	TransferRate  int64
-	TickSize      int64
-	NFTokenMinter string
+	ClearFlag     int64  `json:",omitempty"`
+	Domain        string `json:",omitempty"`
+	EmailHash     string `json:",omitempty"`
+	MessageKey    string `json:",omitempty"`
+	SetFlag       int64  `json:",omitempty"`
+	TransferRate  int64  `json:",omitempty"`
+	TickSize      int64  `json:",omitempty"`
+	NFTokenMinter string `json:",omitempty"`
 }
 
 const (
@@ -174,7 +174,7 @@ const (
 // TransactionType: 'CheckCancel'
 type TransactionCheckCancel struct {
 	BaseTransaction
-	CheckID string
+	CheckID string `json:",omitempty"`
 }
 
 // Attempts to redeem a Check object in the ledger to receive up to the amount
@@ -184,9 +184,9 @@ type TransactionCheckCancel struct {
 // TransactionType: 'CheckCash'
 type TransactionCheckCash struct {
 	BaseTransaction
-	CheckID    string
-	Amount     Amount
-	DeliverMin Amount
+	CheckID    string  `json:",omitempty"`
+	Amount     *Amount `json:",omitempty"`
+	DeliverMin *Amount `json:",omitempty"`
 }
 
 // Create a Check object in the ledger, which is a deferred payment that can be
@@ -196,11 +196,11 @@ type TransactionCheckCash struct {
 // TransactionType: 'CheckCreate'
 type TransactionCheckCreate struct {
 	BaseTransaction
-	Destination    string
-	SendMax        Amount
-	DestinationTag int64
-	Expiration     int64
-	InvoiceID      string
+	Destination    string `json:",omitempty"`
+	SendMax        Amount `json:"SendMax"`
+	DestinationTag int64  `json:",omitempty"`
+	Expiration     int64  `json:",omitempty"`
+	InvoiceID      string `json:",omitempty"`
 }
 
 // A DepositPreauth transaction gives another account pre-approval to deliver
@@ -210,8 +210,8 @@ type TransactionCheckCreate struct {
 // TransactionType: 'DepositPreauth'
 type TransactionDepositPreauth struct {
 	BaseTransaction
-	Authorize   string
-	Unauthorize string
+	Authorize   string `json:",omitempty"`
+	Unauthorize string `json:",omitempty"`
 }
 
 // Return escrowed XRP to the sender.
@@ -219,8 +219,8 @@ type TransactionDepositPreauth struct {
 // TransactionType: 'EscrowCancel'
 type TransactionEscrowCancel struct {
 	BaseTransaction
-	Owner         string
-	OfferSequence int64
+	Owner         string `json:",omitempty"`
+	OfferSequence int64  `json:",omitempty"`
 }
 
 // Sequester XRP until the escrow process either finishes or is canceled.
@@ -228,12 +228,12 @@ type TransactionEscrowCancel struct {
 // TransactionType: 'EscrowCreate'
 type TransactionEscrowCreate struct {
 	BaseTransaction
-	Amount         Amount
-	Destination    string
-	CancelAfter    int64
-	FinishAfter    int64
-	Condition      string
-	DestinationTag int64
+	Amount         Amount `json:"Amount"`
+	Destination    string `json:",omitempty"`
+	CancelAfter    int64  `json:",omitempty"`
+	FinishAfter    int64  `json:",omitempty"`
+	Condition      string `json:",omitempty"`
+	DestinationTag int64  `json:",omitempty"`
 }
 
 // Deliver XRP from a held payment to the recipient.
@@ -241,10 +241,10 @@ type TransactionEscrowCreate struct {
 // TransactionType: 'EscrowFinish'
 type TransactionEscrowFinish struct {
 	BaseTransaction
-	Owner         string
-	OfferSequence int64
-	Condition     string
-	Fulfillment   string
+	Owner         string `json:",omitempty"`
+	OfferSequence int64  `json:",omitempty"`
+	Condition     string `json:",omitempty"`
+	Fulfillment   string `json:",omitempty"`
 }
 
 // An OfferCancel transaction removes an Offer object from the XRP Ledger.
@@ -252,7 +252,7 @@ type TransactionEscrowFinish struct {
 // TransactionType: 'OfferCancel'
 type TransactionOfferCancel struct {
 	BaseTransaction
-	OfferSequence int64
+	OfferSequence int64 `json:",omitempty"`
 }
 
 // An OfferCreate transaction is effectively a limit order . It defines an
@@ -262,11 +262,10 @@ type TransactionOfferCancel struct {
 // TransactionType: 'OfferCreate'
 type TransactionOfferCreate struct {
 	BaseTransaction
-	Flags         int64
-	Expiration    int64
-	OfferSequence int64
-	TakerGets     Amount
-	TakerPays     Amount
+	Expiration    int64  `json:",omitempty"`
+	OfferSequence int64  `json:",omitempty"`
+	TakerGets     Amount `json:"TakerGets"`
+	TakerPays     Amount `json:"TakerPays"`
 }
 
 type OfferCreateFlags struct {
@@ -283,12 +282,11 @@ type OfferCreateFlags struct {
 // TransactionType: 'PaymentChannelClaim'
 type TransactionPaymentChannelClaim struct {
 	BaseTransaction
-	Flags     int64
-	Channel   string
-	Balance   string
-	Amount    string
-	Signature string
-	PublicKey string
+	Channel   string `json:",omitempty"`
+	Balance   string `json:",omitempty"`
+	Amount    string `json:",omitempty"`
+	Signature string `json:",omitempty"`
+	PublicKey string `json:",omitempty"`
 }
 
 type PaymentChannelClaimFlags struct {
@@ -303,12 +301,12 @@ type PaymentChannelClaimFlags struct {
 // TransactionType: 'PaymentChannelCreate'
 type TransactionPaymentChannelCreate struct {
 	BaseTransaction
-	Amount         string
-	Destination    string
-	SettleDelay    int64
-	PublicKey      string
-	CancelAfter    int64
-	DestinationTag int64
+	Amount         string `json:",omitempty"`
+	Destination    string `json:",omitempty"`
+	SettleDelay    int64  `json:",omitempty"`
+	PublicKey      string `json:",omitempty"`
+	CancelAfter    int64  `json:",omitempty"`
+	DestinationTag int64  `json:",omitempty"`
 }
 
 // Add additional XRP to an open payment channel, and optionally update the
@@ -318,9 +316,9 @@ type TransactionPaymentChannelCreate struct {
 // TransactionType: 'PaymentChannelFund'
 type TransactionPaymentChannelFund struct {
 	BaseTransaction
-	Channel    string
-	Amount     string
-	Expiration int64
+	Channel    string `json:",omitempty"`
+	Amount     string `json:",omitempty"`
+	Expiration int64  `json:",omitempty"`
 }
 
 // A SetRegularKey transaction assigns, changes, or removes the regular key
@@ -329,7 +327,7 @@ type TransactionPaymentChannelFund struct {
 // TransactionType: 'SetRegularKey'
 type TransactionSetRegularKey struct {
 	BaseTransaction
-	RegularKey string
+	RegularKey string `json:",omitempty"`
 }
 
 // The SignerListSet transaction creates, replaces, or removes a list of
@@ -338,8 +336,8 @@ type TransactionSetRegularKey struct {
 // TransactionType: 'SignerListSet'
 type TransactionSignerListSet struct {
 	BaseTransaction
-	SignerQuorum  int64
-	SignerEntries []SignerEntry
+	SignerQuorum  int64         `json:"SignerQuorum"`
+	SignerEntries []SignerEntry `json:",omitempty"`
 }
 
 // A TicketCreate transaction sets aside one or more sequence numbers as
@@ -348,7 +346,7 @@ type TransactionSignerListSet struct {
 // TransactionType: 'TicketCreate'
 type TransactionTicketCreate struct {
 	BaseTransaction
-	TicketCount int64
+	TicketCount int64 `json:",omitempty"`
 }
 
 const MAX_TICKETS = 250
@@ -358,10 +356,9 @@ const MAX_TICKETS = 250
 // TransactionType: 'TrustSet'
 type TransactionTrustSet struct {
 	BaseTransaction
-	LimitAmount IssuedCurrencyAmount
-	QualityIn   int64
-	QualityOut  int64
-	Flags       int64
+	LimitAmount IssuedCurrencyAmount `json:"LimitAmount"`
+	QualityIn   int64                `json:",omitempty"`
+	QualityOut  int64                `json:",omitempty"`
 }
 
 type TrustSetFlags struct {
@@ -373,33 +370,6 @@ type TrustSetFlags struct {
 	TfClearFreeze   bool `json:"tfClearFreeze,omitempty"`
 }
 
-type Transaction struct {
-	TransactionAccountDelete
-	TransactionAccountSet
-	TransactionCheckCancel
-	TransactionCheckCash
-	TransactionCheckCreate
-	TransactionDepositPreauth
-	TransactionEscrowCancel
-	TransactionEscrowCreate
-	TransactionEscrowFinish
-	TransactionNFTokenAcceptOffer
-	TransactionNFTokenBurn
-	TransactionNFTokenCancelOffer
-	TransactionNFTokenCreateOffer
-	TransactionNFTokenMint
-	TransactionOfferCancel
-	TransactionOfferCreate
-	TransactionPayment
-	TransactionPaymentChannelClaim
-	TransactionPaymentChannelCreate
-	TransactionPaymentChannelFund
-	TransactionSetRegularKey
-	TransactionSignerListSet
-	TransactionTicketCreate
-	TransactionTrustSet
-}
-
 /*
 * CTID spec: https://github.com/XRPLF/XRPL-Standards/tree/master/XLS-0037d-concise-transaction-identifier-ctid
  */
@@ -410,8 +380,18 @@ type CTID struct {
 	NetworkId        uint64
 }
 
+// CTID field bounds. The lead-in takes the top 4 bits of the 64 bit
+// identifier, leaving 28 bits for the ledger index.
+const (
+	CTIDLeadIn              uint64 = 0xC0000000
+	CTIDMaxLedgerIndex      uint64 = 1<<28 - 1
+	CTIDMaxTransactionIndex uint64 = 1<<16 - 1
+	CTIDMaxNetworkId        uint64 = 1<<16 - 1
+)
+
 /*
-* Encode CTID struct to XLS-37 CTID
+* Encode CTID struct to XLS-37 CTID. Out of range fields produce an invalid
+* CTID; use Validate to check them first.
  */
 func (c *CTID) Encode() string {
 	if c.LeadIn == 0 {
@@ -421,3 +401,44 @@ func (c *CTID) Encode() string {
 	ctid := fmt.Sprintf("%x", cti)
 	return strings.ToUpper(ctid)
 }
+
+/*
+* Validate checks that the CTID fields are within the bounds of XLS-37
+ */
+func (c *CTID) Validate() error {
+	if c.LeadIn != 0 && c.LeadIn != CTIDLeadIn {
+		return fmt.Errorf("invalid CTID lead-in: %X", c.LeadIn)
+	}
+	if c.LedgerIndex > CTIDMaxLedgerIndex {
+		return fmt.Errorf("CTID ledger index out of range: %d", c.LedgerIndex)
+	}
+	if c.TransactionIndex > CTIDMaxTransactionIndex {
+		return fmt.Errorf("CTID transaction index out of range: %d", c.TransactionIndex)
+	}
+	if c.NetworkId > CTIDMaxNetworkId {
+		return fmt.Errorf("CTID network ID out of range: %d", c.NetworkId)
+	}
+	return nil
+}
+
+/*
+* Decode XLS-37 CTID to CTID struct
+ */
+func DecodeCTID(ctid string) (CTID, error) {
+	if len(ctid) != 16 {
+		return CTID{}, errors.New("CTID must be 16 hex characters")
+	}
+	cti, err := strconv.ParseUint(ctid, 16, 64)
+	if err != nil {
+		return CTID{}, fmt.Errorf("invalid CTID: %s", ctid)
+	}
+	if cti>>60 != CTIDLeadIn>>28 {
+		return CTID{}, fmt.Errorf("invalid CTID lead-in: %s", ctid)
+	}
+	return CTID{
+		LeadIn:           CTIDLeadIn,
+		LedgerIndex:      (cti >> 32) & CTIDMaxLedgerIndex,
+		TransactionIndex: (cti >> 16) & CTIDMaxTransactionIndex,
+		NetworkId:        cti & CTIDMaxNetworkId,
+	}, nil
+}
@@ -0,0 +1,159 @@
+package models
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+)
+
+// Transaction is implemented by every transaction type. Use a type switch
+// or type assertion to get at the fields of a particular type:
+//
+//	if payment, ok := tx.(*TransactionPayment); ok {
+//		fmt.Println(payment.Destination, payment.Amount.Value)
+//	}
+type Transaction interface {
+	// TxType returns the TransactionType, e.g. "Payment".
+	TxType() string
+
+	// Base returns the fields common to every transaction.
+	Base() *BaseTransaction
+}
+
+// Base returns the fields common to every transaction.
+func (tx *BaseTransaction) Base() *BaseTransaction { return tx }
+
+func (*TransactionAccountDelete) TxType() string        { return "AccountDelete" }
+func (*TransactionAccountSet) TxType() string           { return "AccountSet" }
+func (*TransactionCheckCancel) TxType() string          { return "CheckCancel" }
+func (*TransactionCheckCash) TxType() string            { return "CheckCash" }
+func (*TransactionCheckCreate) TxType() string          { return "CheckCreate" }
+func (*TransactionDepositPreauth) TxType() string       { return "DepositPreauth" }
+func (*TransactionEscrowCancel) TxType() string         { return "EscrowCancel" }
+func (*TransactionEscrowCreate) TxType() string         { return "EscrowCreate" }
+func (*TransactionEscrowFinish) TxType() string         { return "EscrowFinish" }
+func (*TransactionNFTokenAcceptOffer) TxType() string   { return "NFTokenAcceptOffer" }
+func (*TransactionNFTokenBurn) TxType() string          { return "NFTokenBurn" }
+func (*TransactionNFTokenCancelOffer) TxType() string   { return "NFTokenCancelOffer" }
+func (*TransactionNFTokenCreateOffer) TxType() string   { return "NFTokenCreateOffer" }
+func (*TransactionNFTokenMint) TxType() string          { return "NFTokenMint" }
+func (*TransactionOfferCancel) TxType() string          { return "OfferCancel" }
+func (*TransactionOfferCreate) TxType() string          { return "OfferCreate" }
+func (*TransactionPayment) TxType() string              { return "Payment" }
+func (*TransactionPaymentChannelClaim) TxType() string  { return "PaymentChannelClaim" }
+func (*TransactionPaymentChannelCreate) TxType() string { return "PaymentChannelCreate" }
+func (*TransactionPaymentChannelFund) TxType() string   { return "PaymentChannelFund" }
+func (*TransactionSetRegularKey) TxType() string        { return "SetRegularKey" }
+func (*TransactionSignerListSet) TxType() string        { return "SignerListSet" }
+func (*TransactionTicketCreate) TxType() string         { return "TicketCreate" }
+func (*TransactionTrustSet) TxType() string             { return "TrustSet" }
+
+// UnknownTransaction holds a transaction of a type this package has no
+// struct for. The common fields are decoded; Raw keeps the whole JSON
+// object and is what the transaction marshals back to.
+type UnknownTransaction struct {
+	BaseTransaction
+	Raw json.RawMessage `json:"-"`
+}
+
+// TxType returns the TransactionType of the raw transaction.
+func (tx *UnknownTransaction) TxType() string { return tx.TransactionType }
+
+// MarshalJSON returns the raw transaction.
+func (tx *UnknownTransaction) MarshalJSON() ([]byte, error) {
+	return tx.Raw, nil
+}
+
+// UnmarshalJSON decodes a Payment. API v2 sends DeliverMax instead of
+// Amount; Amount is set from it so that it can be read the same way for
+// either version.
+func (tx *TransactionPayment) UnmarshalJSON(data []byte) error {
+	type payment TransactionPayment
+	var raw struct {
+		payment
+		Amount *Amount `json:"Amount"`
+	}
+	if err := json.Unmarshal(data, &raw); err != nil {
+		return err
+	}
+	*tx = TransactionPayment(raw.payment)
+	switch {
+	case raw.Amount != nil:
+		tx.Amount = *raw.Amount
+	case tx.DeliverMax != nil:
+		tx.Amount = *tx.DeliverMax
+	}
+	return nil
+}
+
+var transactionTypes = map[string]func() Transaction{
+	"AccountDelete":        func() Transaction { return &TransactionAccountDelete{} },
+	"AccountSet":           func() Transaction { return &TransactionAccountSet{} },
+	"CheckCancel":          func() Transaction { return &TransactionCheckCancel{} },
+	"CheckCash":            func() Transaction { return &TransactionCheckCash{} },
+	"CheckCreate":          func() Transaction { return &TransactionCheckCreate{} },
+	"DepositPreauth":       func() Transaction { return &TransactionDepositPreauth{} },
+	"EscrowCancel":         func() Transaction { return &TransactionEscrowCancel{} },
+	"EscrowCreate":         func() Transaction { return &TransactionEscrowCreate{} },
+	"EscrowFinish":         func() Transaction { return &TransactionEscrowFinish{} },
+	"NFTokenAcceptOffer":   func() Transaction { return &TransactionNFTokenAcceptOffer{} },
+	"NFTokenBurn":          func() Transaction { return &TransactionNFTokenBurn{} },
+	"NFTokenCancelOffer":   func() Transaction { return &TransactionNFTokenCancelOffer{} },
+	"NFTokenCreateOffer":   func() Transaction { return &TransactionNFTokenCreateOffer{} },
+	"NFTokenMint":          func() Transaction { return &TransactionNFTokenMint{} },
+	"OfferCancel":          func() Transaction { return &TransactionOfferCancel{} },
+	"OfferCreate":          func() Transaction { return &TransactionOfferCreate{} },
+	"Payment":              func() Transaction { return &TransactionPayment{} },
+	"PaymentChannelClaim":  func() Transaction { return &TransactionPaymentChannelClaim{} },
+	"PaymentChannelCreate": func() Transaction { return &TransactionPaymentChannelCreate{} },
+	"PaymentChannelFund":   func() Transaction { return &TransactionPaymentChannelFund{} },
+	"SetRegularKey":        func() Transaction { return &TransactionSetRegularKey{} },
+	"SignerListSet":        func() Transaction { return &TransactionSignerListSet{} },
+	"TicketCreate":         func() Transaction { return &TransactionTicketCreate{} },
+	"TrustSet":             func() Transaction { return &TransactionTrustSet{} },
+}
+
+// UnmarshalTransaction decodes a transaction in JSON form into the type
+// named by its TransactionType, e.g. *TransactionPayment for a Payment.
+// Types without a struct in this package decode to *UnknownTransaction.
+func UnmarshalTransaction(data []byte) (Transaction, error) {
+	var header struct {
+		TransactionType string
+	}
+	if err := json.Unmarshal(data, &header); err != nil {
+		return nil, err
+	}
+	if header.TransactionType == "" {
+		return nil, errors.New("transaction has no TransactionType")
+	}
+
+	newTx, ok := transactionTypes[header.TransactionType]
+	if !ok {
+		tx := &UnknownTransaction{Raw: append(json.RawMessage(nil), data...)}
+		if err := json.Unmarshal(data, &tx.BaseTransaction); err != nil {
+			return nil, err
+		}
+		return tx, nil
+	}
+	tx := newTx()
+	if err := json.Unmarshal(data, tx); err != nil {
+		return nil, fmt.Errorf("decoding %s transaction: %w", header.TransactionType, err)
+	}
+	return tx, nil
+}
+
+// MarshalTransaction encodes a transaction in JSON form, setting its
+// TransactionType if it is empty. Once decoded into a map, the result can
+// be passed to wallet.Sign.
+//
+// Fields with zero values are omitted, so a tag or flag of 0 cannot be
+// expressed with these structs.
+func MarshalTransaction(tx Transaction) ([]byte, error) {
+	base := tx.Base()
+	if base.TransactionType == "" {
+		base.TransactionType = tx.TxType()
+	} else if base.TransactionType != tx.TxType() {
+		return nil, fmt.Errorf("TransactionType %q does not match %s", base.TransactionType, tx.TxType())
+	}
+	return json.Marshal(tx)
+}
@@ -1,7 +1,12 @@
 package xrpl
 
 import (
+	"errors"
+	"fmt"
 	"strconv"
+	"sync"
+
+	"github.com/xrpscan/xrpl-go/binarycodec"
 )
 
 const XRPL_NATIVE_ASSET = "XRP"
@@ -66,6 +71,18 @@ func (n Network) Name() string {
 	}
 }
 
+// IsTest reports whether the network is a known test or development
+// network. X-addresses for test networks start with "T" instead of "X".
+// Unknown networks, such as custom sidechains, are not test networks.
+func (n Network) IsTest() bool {
+	switch n {
+	case NetworkXrplTestnet, NetworkXrplDevnet, NetworkXrplAmmDevnet, NetworkXahauTestnet:
+		return true
+	default:
+		return false
+	}
+}
+
 func GetNetwork(networkId int) Network {
 	switch networkId {
 	// XRPL networks
@@ -89,3 +106,40 @@ func GetNetwork(networkId int) Network {
 		return NetworkXrplMainnet
 	}
 }
+
+var (
+	networkDefinitionsMutex sync.RWMutex
+	networkDefinitions      = make(map[Network]*binarycodec.Definitions)
+)
+
+// RegisterDefinitions sets the binary codec definitions used for network n.
+// Definitions may be loaded from a file with binarycodec.LoadDefinitionsFile
+// or fetched from a server of that network with Client.ServerDefinitions.
+func RegisterDefinitions(n Network, definitions *binarycodec.Definitions) {
+	networkDefinitionsMutex.Lock()
+	defer networkDefinitionsMutex.Unlock()
+	networkDefinitions[n] = definitions
+}
+
+// ErrNoDefinitions is returned by Network.Definitions for networks whose
+// definitions have not been registered.
+var ErrNoDefinitions = errors.New("no binary codec definitions registered for network")
+
+// Definitions returns the binary codec definitions registered for the
+// network. XRPL networks without registered definitions use the mainnet
+// definitions built into binarycodec. Other networks, such as Xahau or
+// sidechains, have their own field tables, so they return ErrNoDefinitions
+// until definitions are registered for them.
+func (n Network) Definitions() (*binarycodec.Definitions, error) {
+	networkDefinitionsMutex.RLock()
+	defer networkDefinitionsMutex.RUnlock()
+	if definitions, ok := networkDefinitions[n]; ok {
+		return definitions, nil
+	}
+	switch n {
+	case NetworkXrplMainnet, NetworkXrplTestnet, NetworkXrplDevnet, NetworkXrplAmmDevnet:
+		return binarycodec.DefaultDefinitions(), nil
+	default:
+		return nil, fmt.Errorf("%w %s", ErrNoDefinitions, n.Name())
+	}
+}
@@ -0,0 +1,163 @@
+package xrpl
+
+import (
+	"encoding/json"
+	"errors"
+	"strconv"
+)
+
+var errMalformedJSON = errors.New("malformed JSON object")
+
+// scanFields returns the raw JSON values of the requested top-level keys of
+// the JSON object in message. Other values are skipped over without being
+// decoded. Values of absent keys are nil. The returned slices alias message.
+func scanFields(message []byte, keys ...string) ([][]byte, error) {
+	values := make([][]byte, len(keys))
+	i := skipSpace(message, 0)
+	if i >= len(message) || message[i] != '{' {
+		return nil, errMalformedJSON
+	}
+	i = skipSpace(message, i+1)
+	if i < len(message) && message[i] == '}' {
+		return values, nil
+	}
+
+	for {
+		// Key
+		if i >= len(message) || message[i] != '"' {
+			return nil, errMalformedJSON
+		}
+		end, err := skipString(message, i)
+		if err != nil {
+			return nil, err
+		}
+		key := message[i+1 : end-1]
+
+		// Separator
+		i = skipSpace(message, end)
+		if i >= len(message) || message[i] != ':' {
+			return nil, errMalformedJSON
+		}
+		i = skipSpace(message, i+1)
+
+		// Value
+		end, err = skipValue(message, i)
+		if err != nil {
+			return nil, err
+		}
+		for k := range keys {
+			if values[k] == nil && string(key) == keys[k] {
+				values[k] = message[i:end]
+			}
+		}
+
+		i = skipSpace(message, end)
+		if i >= len(message) {
+			return nil, errMalformedJSON
+		}
+		switch message[i] {
+		case ',':
+			i = skipSpace(message, i+1)
+		case '}':
+			return values, nil
+		default:
+			return nil, errMalformedJSON
+		}
+	}
+}
+
+// skipValue returns the offset just past the JSON value starting at i.
+func skipValue(data []byte, i int) (int, error) {
+	if i >= len(data) {
+		return 0, errMalformedJSON
+	}
+	switch data[i] {
+	case '"':
+		return skipString(data, i)
+	case '{', '[':
+		depth := 0
+		for i < len(data) {
+			switch data[i] {
+			case '"':
+				end, err := skipString(data, i)
+				if err != nil {
+					return 0, err
+				}
+				i = end
+				continue
+			case '{', '[':
+				depth++
+			case '}', ']':
+				depth--
+				if depth == 0 {
+					return i + 1, nil
+				}
+			}
+			i++
+		}
+		return 0, errMalformedJSON
+	default:
+		// Numbers, true, false and null run until the next delimiter
+		start := i
+		for i < len(data) {
+			switch data[i] {
+			case ',', '}', ']', ' ', '\t', '\r', '\n':
+				if i == start {
+					return 0, errMalformedJSON
+				}
+				return i, nil
+			}
+			i++
+		}
+		return 0, errMalformedJSON
+	}
+}
+
+// skipString returns the offset just past the JSON string starting at i.
+func skipString(data []byte, i int) (int, error) {
+	for i++; i < len(data); i++ {
+		switch data[i] {
+		case '\\':
+			i++
+		case '"':
+			return i + 1, nil
+		}
+	}
+	return 0, errMalformedJSON
+}
+
+func skipSpace(data []byte, i int) int {
+	for i < len(data) {
+		switch data[i] {
+		case ' ', '\t', '\r', '\n':
+			i++
+		default:
+			return i
+		}
+	}
+	return i
+}
+
+// rawString returns the value of a raw JSON string, or the raw text of any
+// other JSON value. A nil value returns the empty string.
+func rawString(value []byte) string {
+	if len(value) < 2 || value[0] != '"' {
+		return string(value)
+	}
+	for _, b := range value {
+		if b == '\\' {
+			var s string
+			if err := json.Unmarshal(value, &s); err != nil {
+				return ""
+			}
+			return s
+		}
+	}
+	return string(value[1 : len(value)-1])
+}
+
+// rawUint returns the value of a raw JSON number or numeric string.
+func rawUint(value []byte) (uint64, bool) {
+	n, err := strconv.ParseUint(rawString(value), 10, 64)
+	return n, err == nil
+}
@@ -0,0 +1,136 @@
+package shamap
+
+import (
+	"bytes"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/xrpscan/xrpl-go/binarycodec"
+)
+
+// ErrKeyNotFound is returned when a proof is requested for a key that is
+// not in the tree.
+var ErrKeyNotFound = errors.New("key not found in SHAMap")
+
+// Proof shows that a leaf is part of a tree with a given root hash. It
+// holds the leaf and, for every inner node from the root down to the leaf,
+// the hashes of its 16 branches. The branch leading towards the leaf is
+// left empty, since the verifier recomputes it, as are empty branches.
+type Proof struct {
+	Type NodeType   `json:"type"`
+	Key  string     `json:"key"`
+	Data string     `json:"data"`
+	Path [][]string `json:"path"`
+}
+
+// Proof returns an inclusion proof for the leaf with the given key.
+func (m *SHAMap) Proof(key [32]byte) (*Proof, error) {
+	proof := &Proof{Type: m.nodeType, Key: hexUpper(key[:])}
+	node := m.root
+	for depth := 0; ; depth++ {
+		branch := nibble(key, depth)
+		hashes := make([]string, 16)
+		for i, child := range node.children {
+			if i != branch && child != nil {
+				h := childHash(child, m.nodeType)
+				hashes[i] = hexUpper(h[:])
+			}
+		}
+		proof.Path = append(proof.Path, hashes)
+
+		switch child := node.children[branch].(type) {
+		case *innerNode:
+			node = child
+		case *leafNode:
+			if child.key != key {
+				return nil, ErrKeyNotFound
+			}
+			proof.Data = hexUpper(child.data)
+			return proof, nil
+		default:
+			return nil, ErrKeyNotFound
+		}
+	}
+}
+
+// Verify checks that the proof leads to rootHash. For transaction proofs
+// it also checks that the key is the hash of the transaction.
+func (p *Proof) Verify(rootHash string) error {
+	key, err := ParseKey(p.Key)
+	if err != nil {
+		return err
+	}
+	data, err := hex.DecodeString(p.Data)
+	if err != nil {
+		return err
+	}
+	if len(p.Path) == 0 || len(p.Path) > 64 {
+		return fmt.Errorf("invalid proof path length: %d", len(p.Path))
+	}
+	if p.Type == TransactionNode {
+		tx, _, err := binarycodec.DecodeVL(data)
+		if err != nil {
+			return err
+		}
+		if !bytes.Equal(binarycodec.Sha512Half(binarycodec.HashPrefixTransactionID, tx), key[:]) {
+			return errors.New("proof key is not the hash of its transaction")
+		}
+	}
+
+	hash := leafHash(p.Type, key, data)
+	for depth := len(p.Path) - 1; depth >= 0; depth-- {
+		hashes := p.Path[depth]
+		if len(hashes) != 16 {
+			return fmt.Errorf("proof inner node at depth %d has %d branches", depth, len(hashes))
+		}
+		b := make([]byte, 0, len(binarycodec.HashPrefixInnerNode)+16*32)
+		b = append(b, binarycodec.HashPrefixInnerNode...)
+		for i, h := range hashes {
+			var child []byte
+			switch {
+			case i == nibble(key, depth):
+				child = hash[:]
+			case h == "":
+				child = make([]byte, 32)
+			default:
+				child, err = hex.DecodeString(h)
+				if err != nil || len(child) != 32 {
+					return fmt.Errorf("invalid hash in proof: %q", h)
+				}
+			}
+			b = append(b, child...)
+		}
+		copy(hash[:], binarycodec.Sha512Half(b))
+	}
+
+	if !strings.EqualFold(hexUpper(hash[:]), rootHash) {
+		return fmt.Errorf("proof root hash %s does not match %s", hexUpper(hash[:]), rootHash)
+	}
+	return nil
+}
+
+// Transaction returns the hex encoded transaction and metadata blobs held by
+// a transaction proof.
+func (p *Proof) Transaction() (txBlob string, meta string, err error) {
+	if p.Type != TransactionNode {
+		return "", "", errors.New("not a transaction proof")
+	}
+	data, err := hex.DecodeString(p.Data)
+	if err != nil {
+		return "", "", err
+	}
+	tx, rest, err := binarycodec.DecodeVL(data)
+	if err != nil {
+		return "", "", err
+	}
+	metadata, rest, err := binarycodec.DecodeVL(rest)
+	if err != nil {
+		return "", "", err
+	}
+	if len(rest) != 0 {
+		return "", "", errors.New("trailing data in transaction leaf")
+	}
+	return hexUpper(tx), hexUpper(metadata), nil
+}
@@ -0,0 +1,194 @@
+// Package shamap implements the SHAMap, the radix-16 Merkle trie in which
+// the XRP Ledger stores the transactions and state of each ledger. Building
+// the tree from a ledger's contents reproduces the transaction_hash or
+// account_hash of its header.
+// https://xrpl.org/docs/concepts/ledgers/ledger-structure
+package shamap
+
+import (
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/xrpscan/xrpl-go/binarycodec"
+)
+
+// NodeType selects how the leaves of a tree are hashed.
+type NodeType int
+
+const (
+	// TransactionNode leaves hold a transaction and its metadata, keyed by
+	// transaction hash.
+	TransactionNode NodeType = iota
+	// AccountStateNode leaves hold a ledger entry, keyed by its index.
+	AccountStateNode
+)
+
+// ErrDuplicateKey is returned when a key is added to a tree twice.
+var ErrDuplicateKey = errors.New("duplicate SHAMap key")
+
+type leafNode struct {
+	key  [32]byte
+	data []byte
+}
+
+type innerNode struct {
+	children [16]interface{} // nil, *leafNode or *innerNode
+}
+
+// SHAMap is a tree of leaves of one NodeType. The zero value is not usable;
+// create trees with New.
+type SHAMap struct {
+	nodeType NodeType
+	root     *innerNode
+}
+
+// New returns an empty tree.
+func New(nodeType NodeType) *SHAMap {
+	return &SHAMap{nodeType: nodeType, root: &innerNode{}}
+}
+
+// Add inserts a leaf with the given key and serialized data.
+func (m *SHAMap) Add(key [32]byte, data []byte) error {
+	leaf := &leafNode{key: key, data: data}
+	node := m.root
+	for depth := 0; depth < 64; depth++ {
+		branch := nibble(key, depth)
+		switch child := node.children[branch].(type) {
+		case nil:
+			node.children[branch] = leaf
+			return nil
+		case *innerNode:
+			node = child
+		case *leafNode:
+			if child.key == key {
+				return ErrDuplicateKey
+			}
+			// Push the existing leaf one level down and retry there
+			inner := &innerNode{}
+			inner.children[nibble(child.key, depth+1)] = child
+			node.children[branch] = inner
+			node = inner
+		}
+	}
+	return ErrDuplicateKey
+}
+
+// AddTransaction inserts a transaction and its metadata, as hex encoded
+// blobs, keyed by the transaction's hash.
+func (m *SHAMap) AddTransaction(txBlob string, meta string) error {
+	if m.nodeType != TransactionNode {
+		return errors.New("not a transaction tree")
+	}
+	tx, err := hex.DecodeString(txBlob)
+	if err != nil {
+		return err
+	}
+	metadata, err := hex.DecodeString(meta)
+	if err != nil {
+		return err
+	}
+	var key [32]byte
+	copy(key[:], binarycodec.Sha512Half(binarycodec.HashPrefixTransactionID, tx))
+
+	data, err := binarycodec.EncodeVL(tx)
+	if err != nil {
+		return err
+	}
+	vlMeta, err := binarycodec.EncodeVL(metadata)
+	if err != nil {
+		return err
+	}
+	return m.Add(key, append(data, vlMeta...))
+}
+
+// AddLedgerEntry inserts a hex encoded ledger entry under its hex index.
+func (m *SHAMap) AddLedgerEntry(index string, data string) error {
+	if m.nodeType != AccountStateNode {
+		return errors.New("not a state tree")
+	}
+	key, err := ParseKey(index)
+	if err != nil {
+		return err
+	}
+	entry, err := hex.DecodeString(data)
+	if err != nil {
+		return err
+	}
+	return m.Add(key, entry)
+}
+
+// Hash returns the root hash of the tree as uppercase hex. The hash of an
+// empty tree is zero.
+func (m *SHAMap) Hash() string {
+	hash := m.root.hash(m.nodeType)
+	return hexUpper(hash[:])
+}
+
+func (n *innerNode) hash(nodeType NodeType) [32]byte {
+	empty := true
+	data := make([]byte, 0, len(binarycodec.HashPrefixInnerNode)+16*32)
+	data = append(data, binarycodec.HashPrefixInnerNode...)
+	for _, child := range n.children {
+		h := childHash(child, nodeType)
+		if child != nil {
+			empty = false
+		}
+		data = append(data, h[:]...)
+	}
+	var hash [32]byte
+	if !empty {
+		copy(hash[:], binarycodec.Sha512Half(data))
+	}
+	return hash
+}
+
+func (n *leafNode) hash(nodeType NodeType) [32]byte {
+	return leafHash(nodeType, n.key, n.data)
+}
+
+func childHash(child interface{}, nodeType NodeType) [32]byte {
+	switch c := child.(type) {
+	case *innerNode:
+		return c.hash(nodeType)
+	case *leafNode:
+		return c.hash(nodeType)
+	default:
+		return [32]byte{}
+	}
+}
+
+func leafHash(nodeType NodeType, key [32]byte, data []byte) [32]byte {
+	prefix := binarycodec.HashPrefixLeafNode
+	if nodeType == TransactionNode {
+		prefix = binarycodec.HashPrefixTransactionNode
+	}
+	var hash [32]byte
+	copy(hash[:], binarycodec.Sha512Half(prefix, data, key[:]))
+	return hash
+}
+
+// nibble returns the branch a key takes at the given depth.
+func nibble(key [32]byte, depth int) int {
+	b := key[depth/2]
+	if depth%2 == 0 {
+		return int(b >> 4)
+	}
+	return int(b & 0x0F)
+}
+
+// ParseKey decodes a 64 character hex key.
+func ParseKey(key string) ([32]byte, error) {
+	var k [32]byte
+	b, err := hex.DecodeString(key)
+	if err != nil || len(b) != 32 {
+		return k, fmt.Errorf("invalid SHAMap key: %q", key)
+	}
+	copy(k[:], b)
+	return k, nil
+}
+
+func hexUpper(b []byte) string {
+	return strings.ToUpper(hex.EncodeToString(b))
+}
@@ -0,0 +1,228 @@
+package shamap
+
+import (
+	"crypto/sha512"
+	"encoding/hex"
+	"errors"
+	"math/rand"
+	"strings"
+	"testing"
+)
+
+// referenceHash hashes a set of leaves the way rippled describes the
+// SHAMap, independently of the tree built by Add: an inner node is the
+// SHA-512Half of "MIN\0" and its 16 child hashes, empty subtrees hash to
+// zero, and a subtree holding a single leaf is that leaf.
+func referenceHash(nodeType NodeType, leaves map[[32]byte][]byte, depth int) [32]byte {
+	var zero [32]byte
+	if len(leaves) == 0 {
+		return zero
+	}
+	if len(leaves) == 1 && depth > 0 {
+		for key, data := range leaves {
+			prefix := "MLN\x00"
+			if nodeType == TransactionNode {
+				prefix = "SND\x00"
+			}
+			return sha512Half([]byte(prefix), data, key[:])
+		}
+	}
+	var branches [16]map[[32]byte][]byte
+	for key, data := range leaves {
+		b := nibble(key, depth)
+		if branches[b] == nil {
+			branches[b] = make(map[[32]byte][]byte)
+		}
+		branches[b][key] = data
+	}
+	buf := []byte("MIN\x00")
+	for _, branch := range branches {
+		h := referenceHash(nodeType, branch, depth+1)
+		buf = append(buf, h[:]...)
+	}
+	return sha512Half(buf)
+}
+
+func sha512Half(data ...[]byte) [32]byte {
+	h := sha512.New()
+	for _, d := range data {
+		h.Write(d)
+	}
+	var out [32]byte
+	copy(out[:], h.Sum(nil))
+	return out
+}
+
+func randomLeaves(r *rand.Rand, n int) map[[32]byte][]byte {
+	leaves := make(map[[32]byte][]byte, n)
+	for len(leaves) < n {
+		var key [32]byte
+		r.Read(key[:])
+		// Share leading nibbles between some keys so that the tree gets
+		// deeper than one level.
+		if len(leaves)%3 == 0 {
+			key[0], key[1] = 0xAB, 0xC0|key[1]&0x0F
+		}
+		data := make([]byte, 1+r.Intn(64))
+		r.Read(data)
+		leaves[key] = data
+	}
+	return leaves
+}
+
+func TestEmptyTree(t *testing.T) {
+	if h := New(AccountStateNode).Hash(); h != strings.Repeat("0", 64) {
+		t.Errorf("empty tree hash %s", h)
+	}
+}
+
+func TestHashMatchesReference(t *testing.T) {
+	r := rand.New(rand.NewSource(1))
+	for _, nodeType := range []NodeType{TransactionNode, AccountStateNode} {
+		for _, n := range []int{1, 2, 17, 300} {
+			leaves := randomLeaves(r, n)
+			m := New(nodeType)
+			for key, data := range leaves {
+				if err := m.Add(key, data); err != nil {
+					t.Fatal(err)
+				}
+			}
+			want := referenceHash(nodeType, leaves, 0)
+			if got := m.Hash(); got != hexUpper(want[:]) {
+				t.Errorf("type %d, %d leaves: hash %s, want %X", nodeType, n, got, want)
+			}
+		}
+	}
+}
+
+func TestInsertionOrder(t *testing.T) {
+	r := rand.New(rand.NewSource(2))
+	leaves := randomLeaves(r, 50)
+	keys := make([][32]byte, 0, len(leaves))
+	for key := range leaves {
+		keys = append(keys, key)
+	}
+	var first string
+	for i := 0; i < 3; i++ {
+		r.Shuffle(len(keys), func(a, b int) { keys[a], keys[b] = keys[b], keys[a] })
+		m := New(AccountStateNode)
+		for _, key := range keys {
+			if err := m.Add(key, leaves[key]); err != nil {
+				t.Fatal(err)
+			}
+		}
+		if i == 0 {
+			first = m.Hash()
+		} else if m.Hash() != first {
+			t.Errorf("hash depends on insertion order: %s and %s", first, m.Hash())
+		}
+	}
+}
+
+func TestDuplicateKey(t *testing.T) {
+	m := New(AccountStateNode)
+	var key [32]byte
+	key[0] = 0x12
+	if err := m.Add(key, []byte{1}); err != nil {
+		t.Fatal(err)
+	}
+	if err := m.Add(key, []byte{2}); !errors.Is(err, ErrDuplicateKey) {
+		t.Errorf("got %v, want ErrDuplicateKey", err)
+	}
+}
+
+func TestTransactionTree(t *testing.T) {
+	m := New(TransactionNode)
+	txBlob := "120000"
+	meta := "201C00000000F8E5110061E1F1031000"
+	if err := m.AddTransaction(txBlob, meta); err != nil {
+		t.Fatal(err)
+	}
+	if err := m.AddTransaction(txBlob, meta); !errors.Is(err, ErrDuplicateKey) {
+		t.Errorf("got %v, want ErrDuplicateKey", err)
+	}
+	if err := New(AccountStateNode).AddTransaction(txBlob, meta); err == nil {
+		t.Error("added a transaction to a state tree")
+	}
+
+	// The key is the transaction ID, and the leaf holds both blobs with
+	// length prefixes.
+	tx, _ := hex.DecodeString(txBlob)
+	key := sha512Half([]byte("TXN\x00"), tx)
+	data, _ := hex.DecodeString("03" + txBlob + "10" + meta)
+	want := referenceHash(TransactionNode, map[[32]byte][]byte{key: data}, 0)
+	if got := m.Hash(); got != hexUpper(want[:]) {
+		t.Errorf("hash %s, want %X", got, want)
+	}
+
+	proof, err := m.Proof(key)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := proof.Verify(m.Hash()); err != nil {
+		t.Error(err)
+	}
+	gotTx, gotMeta, err := proof.Transaction()
+	if err != nil || gotTx != txBlob || gotMeta != meta {
+		t.Errorf("proof holds %s %s (%v)", gotTx, gotMeta, err)
+	}
+}
+
+func TestProofs(t *testing.T) {
+	r := rand.New(rand.NewSource(3))
+	leaves := randomLeaves(r, 100)
+	m := New(AccountStateNode)
+	for key, data := range leaves {
+		if err := m.Add(key, data); err != nil {
+			t.Fatal(err)
+		}
+	}
+	root := m.Hash()
+
+	for key := range leaves {
+		proof, err := m.Proof(key)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if err := proof.Verify(root); err != nil {
+			t.Fatalf("%X: %v", key, err)
+		}
+
+		tampered := *proof
+		tampered.Data = "00" + proof.Data
+		if tampered.Verify(root) == nil {
+			t.Errorf("%X: proof with altered data verified", key)
+		}
+		if proof.Verify(strings.Repeat("0", 64)) == nil {
+			t.Errorf("%X: proof verified against another root", key)
+		}
+	}
+
+	var missing [32]byte
+	missing[0] = 0xAB
+	missing[1] = 0xC5
+	if _, ok := leaves[missing]; !ok {
+		if _, err := m.Proof(missing); !errors.Is(err, ErrKeyNotFound) {
+			t.Errorf("got %v, want ErrKeyNotFound", err)
+		}
+	}
+}
+
+func TestTransactionProofKey(t *testing.T) {
+	// A transaction proof must be keyed by the transaction's hash, so that
+	// a leaf cannot be presented as some other transaction.
+	m := New(TransactionNode)
+	var key [32]byte
+	key[0] = 0x42
+	data, _ := hex.DecodeString("03120000" + "00")
+	if err := m.Add(key, data); err != nil {
+		t.Fatal(err)
+	}
+	proof, err := m.Proof(key)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if proof.Verify(m.Hash()) == nil {
+		t.Error("proof keyed by something other than the transaction hash verified")
+	}
+}
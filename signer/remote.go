@@ -0,0 +1,128 @@
+// Package signer connects wallet.Signer to signing services over HTTP, so
+// that private keys can be kept out of the process that builds and submits
+// transactions. Server is a stand-in signing service for tests.
+//
+// The protocol is JSON over HTTP:
+//
+//	GET  /public_key  -> {"public_key": "<hex>"}
+//	POST /sign        {"digest": "<hex>"} -> {"signature": "<hex>"}
+package signer
+
+import (
+	"bytes"
+	"context"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/xrpscan/xrpl-go/wallet"
+)
+
+type publicKeyResponse struct {
+	PublicKey string `json:"public_key"`
+}
+
+type signRequest struct {
+	Digest string `json:"digest"`
+}
+
+type signResponse struct {
+	Signature string `json:"signature"`
+}
+
+// DefaultTimeout bounds a Sign call when the Remote has no Timeout set.
+const DefaultTimeout = 30 * time.Second
+
+// Remote is a wallet.Signer backed by a signing service.
+type Remote struct {
+	// Timeout bounds each Sign call, which has no context of its own.
+	// NewRemote sets it to DefaultTimeout.
+	Timeout time.Duration
+
+	url       string
+	client    *http.Client
+	publicKey string
+}
+
+var _ wallet.Signer = (*Remote)(nil)
+
+// NewRemote connects to the signing service at url and fetches its public
+// key. If client is nil, http.DefaultClient is used.
+func NewRemote(ctx context.Context, url string, client *http.Client) (*Remote, error) {
+	if client == nil {
+		client = http.DefaultClient
+	}
+	r := &Remote{Timeout: DefaultTimeout, url: strings.TrimSuffix(url, "/"), client: client}
+	var res publicKeyResponse
+	if err := r.do(ctx, http.MethodGet, "/public_key", nil, &res); err != nil {
+		return nil, err
+	}
+	key, err := hex.DecodeString(res.PublicKey)
+	if err != nil || len(key) != 33 {
+		return nil, fmt.Errorf("signing service returned an invalid public key: %q", res.PublicKey)
+	}
+	r.publicKey = strings.ToUpper(res.PublicKey)
+	return r, nil
+}
+
+// PublicKey returns the public key of the signing service.
+func (r *Remote) PublicKey() string {
+	return r.publicKey
+}
+
+// Sign asks the signing service to sign digest, giving up after Timeout.
+func (r *Remote) Sign(digest []byte) ([]byte, error) {
+	timeout := r.Timeout
+	if timeout <= 0 {
+		timeout = DefaultTimeout
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), timeout)
+	defer cancel()
+	return r.SignContext(ctx, digest)
+}
+
+// SignContext asks the signing service to sign digest, giving up when ctx
+// is done.
+func (r *Remote) SignContext(ctx context.Context, digest []byte) ([]byte, error) {
+	var res signResponse
+	req := signRequest{Digest: hex.EncodeToString(digest)}
+	if err := r.do(ctx, http.MethodPost, "/sign", req, &res); err != nil {
+		return nil, err
+	}
+	return hex.DecodeString(res.Signature)
+}
+
+func (r *Remote) do(ctx context.Context, method string, path string, body interface{}, res interface{}) error {
+	var reader io.Reader
+	if body != nil {
+		data, err := json.Marshal(body)
+		if err != nil {
+			return err
+		}
+		reader = bytes.NewReader(data)
+	}
+	req, err := http.NewRequestWithContext(ctx, method, r.url+path, reader)
+	if err != nil {
+		return err
+	}
+	if body != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
+	resp, err := r.client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("signing service: %s", resp.Status)
+	}
+	if err := json.NewDecoder(resp.Body).Decode(res); err != nil {
+		return errors.New("signing service returned an invalid response")
+	}
+	return nil
+}
@@ -0,0 +1,170 @@
+package signer
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/decred/dcrd/dcrec/secp256k1/v4"
+	"github.com/xrpscan/xrpl-go/wallet"
+)
+
+const (
+	secpSeed    = "sp5fghtJtpUorTwvof1NpDXAzNwf5"
+	ed25519Seed = "sEdSKaCy2JT7JaM7v95H9SxkhP9wS2r"
+)
+
+func mustWallet(t *testing.T, seed string) *wallet.Wallet {
+	t.Helper()
+	w, err := wallet.FromSeed(seed)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return w
+}
+
+func testPayment(account string) map[string]interface{} {
+	return map[string]interface{}{
+		"TransactionType": "Payment",
+		"Account":         account,
+		"Destination":     "rHb9CJAWyB4rj91VRWn96DkukG4bwdtyTh",
+		"Amount":          "1000000",
+		"Fee":             "12",
+		"Sequence":        7,
+	}
+}
+
+// serve runs a signing service for signer and connects a Remote to it.
+func serve(t *testing.T, signer wallet.Signer) *Remote {
+	t.Helper()
+	server := httptest.NewServer(NewServer(signer))
+	t.Cleanup(server.Close)
+	remote, err := NewRemote(context.Background(), server.URL, server.Client())
+	if err != nil {
+		t.Fatal(err)
+	}
+	return remote
+}
+
+// signerFunc wraps a signer, rewriting its signatures.
+type signerFunc struct {
+	wallet.Signer
+	rewrite func([]byte) []byte
+}
+
+func (s signerFunc) Sign(digest []byte) ([]byte, error) {
+	sig, err := s.Signer.Sign(digest)
+	if err != nil {
+		return nil, err
+	}
+	return s.rewrite(sig), nil
+}
+
+func TestRemoteSign(t *testing.T) {
+	for _, seed := range []string{secpSeed, ed25519Seed} {
+		w := mustWallet(t, seed)
+		remote := serve(t, w.Signer())
+		if remote.PublicKey() != w.PublicKey {
+			t.Errorf("%s: public key %s, want %s", seed, remote.PublicKey(), w.PublicKey)
+		}
+
+		// Both key types sign deterministically, so the remote and local
+		// signatures are identical.
+		tx := testPayment(w.ClassicAddress)
+		wantBlob, wantHash, err := wallet.Sign(tx, w)
+		if err != nil {
+			t.Fatal(err)
+		}
+		txBlob, hash, err := wallet.SignWith(tx, remote)
+		if err != nil {
+			t.Fatalf("%s: %v", seed, err)
+		}
+		if txBlob != wantBlob || hash != wantHash {
+			t.Errorf("%s: remote signed %s %s, want %s %s", seed, hash, txBlob, wantHash, wantBlob)
+		}
+	}
+}
+
+func TestRemoteSignNormalizes(t *testing.T) {
+	w := mustWallet(t, secpSeed)
+	tx := testPayment(w.ClassicAddress)
+	wantBlob, _, err := wallet.Sign(tx, w)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// A service that returns R||S with the high S value, as many HSMs do.
+	remote := serve(t, signerFunc{w.Signer(), func(der []byte) []byte {
+		// 30 len 02 rLen R 02 sLen S
+		rLen := int(der[3])
+		var r, s secp256k1.ModNScalar
+		r.SetByteSlice(bytes.TrimLeft(der[4:4+rLen], "\x00"))
+		s.SetByteSlice(bytes.TrimLeft(der[6+rLen:], "\x00"))
+		s.Negate()
+		rBytes, sBytes := r.Bytes(), s.Bytes()
+		return append(rBytes[:], sBytes[:]...)
+	}})
+	txBlob, _, err := wallet.SignWith(tx, remote)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if txBlob != wantBlob {
+		t.Errorf("high S signature not normalized:\n got %s\nwant %s", txBlob, wantBlob)
+	}
+}
+
+func TestRemoteSignRejectsBadSignature(t *testing.T) {
+	for _, seed := range []string{secpSeed, ed25519Seed} {
+		w := mustWallet(t, seed)
+		remote := serve(t, signerFunc{w.Signer(), func(sig []byte) []byte {
+			sig[len(sig)-1] ^= 1
+			return sig
+		}})
+		if _, _, err := wallet.SignWith(testPayment(w.ClassicAddress), remote); err == nil {
+			t.Errorf("%s: accepted a corrupted signature", seed)
+		}
+	}
+}
+
+func TestRemoteSignTimeout(t *testing.T) {
+	w := mustWallet(t, secpSeed)
+	signingService := NewServer(w.Signer())
+	release := make(chan struct{})
+	server := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/sign" {
+			select {
+			case <-release:
+			case <-r.Context().Done():
+			}
+			return
+		}
+		signingService.ServeHTTP(rw, r)
+	}))
+	defer server.Close()
+	defer close(release)
+
+	remote, err := NewRemote(context.Background(), server.URL, server.Client())
+	if err != nil {
+		t.Fatal(err)
+	}
+	digest := make([]byte, 32)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+	if _, err := remote.SignContext(ctx, digest); !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("SignContext: got %v, want %v", err, context.DeadlineExceeded)
+	}
+
+	remote.Timeout = 50 * time.Millisecond
+	start := time.Now()
+	if _, err := remote.Sign(digest); !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("Sign: got %v, want %v", err, context.DeadlineExceeded)
+	}
+	if elapsed := time.Since(start); elapsed > 5*time.Second {
+		t.Errorf("Sign returned after %v", elapsed)
+	}
+}
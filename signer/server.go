@@ -0,0 +1,66 @@
+package signer
+
+import (
+	"encoding/hex"
+	"encoding/json"
+	"net/http"
+
+	"github.com/xrpscan/xrpl-go/wallet"
+)
+
+// Server is a stand-in signing service that signs with a key held in
+// memory. It speaks the protocol Remote expects and is meant for tests,
+// for example with httptest.NewServer.
+type Server struct {
+	signer wallet.Signer
+	mux    *http.ServeMux
+}
+
+// NewServer returns a signing service for signer, typically the Signer of
+// a test wallet.
+func NewServer(signer wallet.Signer) *Server {
+	s := &Server{signer: signer, mux: http.NewServeMux()}
+	s.mux.HandleFunc("/public_key", s.handlePublicKey)
+	s.mux.HandleFunc("/sign", s.handleSign)
+	return s
+}
+
+func (s *Server) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	s.mux.ServeHTTP(w, r)
+}
+
+func (s *Server) handlePublicKey(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+	writeJSON(w, publicKeyResponse{PublicKey: s.signer.PublicKey()})
+}
+
+func (s *Server) handleSign(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+	var req signRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	digest, err := hex.DecodeString(req.Digest)
+	if err != nil {
+		http.Error(w, "invalid digest", http.StatusBadRequest)
+		return
+	}
+	sig, err := s.signer.Sign(digest)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	writeJSON(w, signResponse{Signature: hex.EncodeToString(sig)})
+}
+
+func writeJSON(w http.ResponseWriter, v interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(v)
+}
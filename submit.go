@@ -0,0 +1,24 @@
+package xrpl
+
+import (
+	"github.com/xrpscan/xrpl-go/binarycodec"
+	"github.com/xrpscan/xrpl-go/methods"
+)
+
+// SubmitMultisigned submits a multi-signed transaction in JSON form.
+func (c *Client) SubmitMultisigned(req methods.SubmitMultisignedRequest) (methods.SubmitMultisignedResponse, error) {
+	req.Command = "submit_multisigned"
+	var res methods.SubmitMultisignedResponse
+	err := c.RequestInto(req, &res)
+	return res, err
+}
+
+// SubmitMultisignedBlob submits a multi-signed tx_blob, such as one produced
+// by wallet.Combine, with submit_multisigned.
+func (c *Client) SubmitMultisignedBlob(txBlob string) (methods.SubmitMultisignedResponse, error) {
+	tx, err := binarycodec.Decode(txBlob)
+	if err != nil {
+		return methods.SubmitMultisignedResponse{}, err
+	}
+	return c.SubmitMultisigned(methods.SubmitMultisignedRequest{TxJson: tx})
+}
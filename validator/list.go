@@ -0,0 +1,289 @@
+package validator
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/xrpscan/xrpl-go/addresscodec"
+	"github.com/xrpscan/xrpl-go/keypairs"
+)
+
+// Seconds between the UNIX epoch and the Ripple epoch, in which list dates
+// are given.
+const rippleEpoch int64 = 946684800
+
+// PublishedList is a validator list as served by a publisher such as
+// https://vl.ripple.com or https://vl.xrplf.org. Version 1 lists carry a
+// single blob; version 2 lists carry blobs_v2, which may include lists that
+// take effect in the future.
+type PublishedList struct {
+	PublicKey string     `json:"public_key"`
+	Manifest  string     `json:"manifest"`
+	Blob      string     `json:"blob,omitempty"`
+	Signature string     `json:"signature,omitempty"`
+	BlobsV2   []ListBlob `json:"blobs_v2,omitempty"`
+	Version   int        `json:"version"`
+}
+
+// ListBlob is a signed list in blobs_v2. Manifest is set when the blob is
+// signed with a different key than the list's manifest delegates to.
+type ListBlob struct {
+	Blob      string `json:"blob"`
+	Signature string `json:"signature"`
+	Manifest  string `json:"manifest,omitempty"`
+}
+
+// List is the verified content of a validator list blob. Keys are base58
+// node public keys.
+type List struct {
+	PublisherKey string
+	Sequence     uint32
+	Effective    time.Time // zero if the list is effective immediately
+	Expiration   time.Time
+	Validators   []ListedValidator
+}
+
+// ListedValidator is a validator trusted by a list, with the manifest the
+// publisher included for it, if any.
+type ListedValidator struct {
+	MasterKey string
+	Manifest  *Manifest
+}
+
+type listContent struct {
+	Sequence   uint32 `json:"sequence"`
+	Effective  int64  `json:"effective"`
+	Expiration int64  `json:"expiration"`
+	Validators []struct {
+		ValidationPublicKey string `json:"validation_public_key"`
+		Manifest            string `json:"manifest"`
+	} `json:"validators"`
+}
+
+// ParsePublishedList parses a validator list in JSON form. It is not
+// verified.
+func ParsePublishedList(data []byte) (*PublishedList, error) {
+	p := &PublishedList{}
+	if err := json.Unmarshal(data, p); err != nil {
+		return nil, err
+	}
+	if p.PublicKey == "" || p.Manifest == "" {
+		return nil, errors.New("validator list has no public key or manifest")
+	}
+	return p, nil
+}
+
+// LoadPublishedList reads a validator list from a file.
+func LoadPublishedList(path string) (*PublishedList, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	return ParsePublishedList(data)
+}
+
+// FetchPublishedList downloads a validator list from a publisher site.
+func FetchPublishedList(ctx context.Context, url string) (*PublishedList, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return nil, err
+	}
+	res, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer res.Body.Close()
+	if res.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("fetching validator list from %s: %s", url, res.Status)
+	}
+	data, err := io.ReadAll(res.Body)
+	if err != nil {
+		return nil, err
+	}
+	return ParsePublishedList(data)
+}
+
+// Verify checks that the list was published by publisherKey, the hex
+// encoded master key of the publisher as configured in validators.txt, and
+// returns every list it carries ordered by sequence. Expired lists are
+// returned too; use Current to select the list in effect.
+func (p *PublishedList) Verify(publisherKey string) ([]*List, error) {
+	if !strings.EqualFold(p.PublicKey, publisherKey) {
+		return nil, fmt.Errorf("validator list is published by %s, not %s", p.PublicKey, publisherKey)
+	}
+	manifest, err := publisherManifest(p.Manifest, publisherKey)
+	if err != nil {
+		return nil, err
+	}
+
+	blobs := p.BlobsV2
+	if p.Version < 2 {
+		blobs = []ListBlob{{Blob: p.Blob, Signature: p.Signature}}
+	}
+	if len(blobs) == 0 {
+		return nil, errors.New("validator list has no blobs")
+	}
+	lists := make([]*List, 0, len(blobs))
+	for _, blob := range blobs {
+		m := manifest
+		if blob.Manifest != "" {
+			if m, err = publisherManifest(blob.Manifest, publisherKey); err != nil {
+				return nil, err
+			}
+		}
+		list, err := verifyBlob(blob, m)
+		if err != nil {
+			return nil, err
+		}
+		lists = append(lists, list)
+	}
+	sort.Slice(lists, func(i, j int) bool {
+		return lists[i].Sequence < lists[j].Sequence
+	})
+	return lists, nil
+}
+
+// Current verifies the list and returns the one in effect at now: the
+// list with the highest sequence that is effective and not yet expired.
+func (p *PublishedList) Current(publisherKey string, now time.Time) (*List, error) {
+	lists, err := p.Verify(publisherKey)
+	if err != nil {
+		return nil, err
+	}
+	for i := len(lists) - 1; i >= 0; i-- {
+		if lists[i].Active(now) {
+			return lists[i], nil
+		}
+	}
+	return nil, errors.New("validator list has expired or is not yet effective")
+}
+
+// Active reports whether the list is in effect at now.
+func (l *List) Active(now time.Time) bool {
+	return !now.Before(l.Effective) && now.Before(l.Expiration)
+}
+
+// MasterKeys returns the master keys of the listed validators.
+func (l *List) MasterKeys() []string {
+	keys := make([]string, len(l.Validators))
+	for i, v := range l.Validators {
+		keys[i] = v.MasterKey
+	}
+	return keys
+}
+
+// Contains reports whether masterKey is a listed validator.
+func (l *List) Contains(masterKey string) bool {
+	for _, v := range l.Validators {
+		if v.MasterKey == masterKey {
+			return true
+		}
+	}
+	return false
+}
+
+// Quorum returns the number of listed validators that must validate a
+// ledger for it to be considered validated: 80% of the list, rounded up.
+func (l *List) Quorum() int {
+	return (len(l.Validators)*4 + 4) / 5
+}
+
+// ApplyManifests adds the manifests included in the list to a registry.
+// Manifests older than those already in the registry are skipped.
+func (l *List) ApplyManifests(r *Registry) error {
+	for _, v := range l.Validators {
+		if v.Manifest == nil {
+			continue
+		}
+		if err := r.Apply(v.Manifest); err != nil && !errors.Is(err, ErrStaleManifest) {
+			return fmt.Errorf("manifest for %s: %w", v.MasterKey, err)
+		}
+	}
+	return nil
+}
+
+// publisherManifest decodes and verifies a publisher manifest for the hex
+// encoded publisherKey.
+func publisherManifest(encoded string, publisherKey string) (*Manifest, error) {
+	m, err := DecodeManifest(encoded)
+	if err != nil {
+		return nil, fmt.Errorf("publisher manifest: %w", err)
+	}
+	if err := m.Verify(); err != nil {
+		return nil, fmt.Errorf("publisher manifest: %w", err)
+	}
+	masterKey, err := addresscodec.DecodeNodePublic(m.MasterKey)
+	if err != nil {
+		return nil, err
+	}
+	if !strings.EqualFold(hexUpper(masterKey), publisherKey) {
+		return nil, errors.New("publisher manifest is for another key")
+	}
+	if m.Revoked() {
+		return nil, errors.New("publisher key has been revoked")
+	}
+	return m, nil
+}
+
+// verifyBlob checks the signature of a blob by the signing key of the
+// publisher manifest and decodes it.
+func verifyBlob(blob ListBlob, m *Manifest) (*List, error) {
+	data, err := base64.StdEncoding.DecodeString(blob.Blob)
+	if err != nil {
+		return nil, err
+	}
+	signingKey, err := addresscodec.DecodeNodePublic(m.SigningKey)
+	if err != nil {
+		return nil, err
+	}
+	if !keypairs.Verify(data, blob.Signature, hexUpper(signingKey)) {
+		return nil, fmt.Errorf("validator list blob: %w", ErrInvalidSignature)
+	}
+
+	var content listContent
+	if err := json.Unmarshal(data, &content); err != nil {
+		return nil, err
+	}
+	list := &List{
+		PublisherKey: m.MasterKey,
+		Sequence:     content.Sequence,
+		Expiration:   time.Unix(content.Expiration+rippleEpoch, 0).UTC(),
+		Validators:   make([]ListedValidator, 0, len(content.Validators)),
+	}
+	if content.Effective != 0 {
+		list.Effective = time.Unix(content.Effective+rippleEpoch, 0).UTC()
+	}
+	for _, v := range content.Validators {
+		key, err := hex.DecodeString(v.ValidationPublicKey)
+		if err != nil {
+			return nil, fmt.Errorf("invalid validator key %q", v.ValidationPublicKey)
+		}
+		masterKey, err := addresscodec.EncodeNodePublic(key)
+		if err != nil {
+			return nil, err
+		}
+		listed := ListedValidator{MasterKey: masterKey}
+		if v.Manifest != "" {
+			m, err := DecodeManifest(v.Manifest)
+			if err != nil {
+				return nil, fmt.Errorf("manifest for %s: %w", masterKey, err)
+			}
+			if m.MasterKey != masterKey {
+				return nil, fmt.Errorf("manifest for %s is for %s", masterKey, m.MasterKey)
+			}
+			listed.Manifest = m
+		}
+		list.Validators = append(list.Validators, listed)
+	}
+	return list, nil
+}
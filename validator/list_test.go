@@ -0,0 +1,261 @@
+package validator
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/xrpscan/xrpl-go/addresscodec"
+	"github.com/xrpscan/xrpl-go/keypairs"
+)
+
+// No list captured from vl.ripple.com or vl.xrplf.org is at hand, so the
+// publisher and its lists are made up. They are signed and laid out as the
+// publishers do.
+
+// listPublisher signs validator lists with the ephemeral key of its
+// current manifest.
+type listPublisher struct {
+	*testValidator
+	signingPrivate string
+	manifest       string
+}
+
+func newListPublisher(t *testing.T, sequence uint32, signingSeed string) *listPublisher {
+	t.Helper()
+	p := &listPublisher{testValidator: newTestValidator(t, "sEdSuqBPSQaood2DmNYVkwWTn1oQTj2")}
+	p.rotate(t, sequence, signingSeed)
+	return p
+}
+
+// rotate delegates to a new signing key.
+func (p *listPublisher) rotate(t *testing.T, sequence uint32, signingSeed string) {
+	t.Helper()
+	m := p.testValidator.manifest(t, sequence, signingSeed)
+	p.signingPrivate, _ = ephemeralKey(t, signingSeed)
+	var err error
+	if p.manifest, err = m.Encode(); err != nil {
+		t.Fatal(err)
+	}
+}
+
+// publicKey returns the master key as configured in validators.txt.
+func (p *listPublisher) publicKey() string {
+	return p.masterPublic
+}
+
+// blob signs a list of the validators whose manifests are given. Dates are
+// in seconds since the Ripple epoch; effective is omitted if zero.
+func (p *listPublisher) blob(t *testing.T, sequence uint32, effective, expiration int64, manifests ...*Manifest) ListBlob {
+	t.Helper()
+	content := map[string]interface{}{
+		"sequence":   sequence,
+		"expiration": expiration,
+	}
+	if effective != 0 {
+		content["effective"] = effective
+	}
+	listed := make([]map[string]string, len(manifests))
+	for i, m := range manifests {
+		masterKey, err := addresscodec.DecodeNodePublic(m.MasterKey)
+		if err != nil {
+			t.Fatal(err)
+		}
+		encoded, err := m.Encode()
+		if err != nil {
+			t.Fatal(err)
+		}
+		listed[i] = map[string]string{"validation_public_key": hexUpper(masterKey), "manifest": encoded}
+	}
+	content["validators"] = listed
+	data, err := json.Marshal(content)
+	if err != nil {
+		t.Fatal(err)
+	}
+	signature, err := keypairs.Sign(data, p.signingPrivate)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return ListBlob{Blob: base64.StdEncoding.EncodeToString(data), Signature: signature}
+}
+
+func rippleTime(seconds int64) time.Time {
+	return time.Unix(seconds+rippleEpoch, 0).UTC()
+}
+
+func TestPublishedListV1(t *testing.T) {
+	publisher := newListPublisher(t, 1, "sp5fghtJtpUorTwvof1NpDXAzNwf5")
+	validator := newTestValidator(t, "sEdSKaCy2JT7JaM7v95H9SxkhP9wS2r")
+	blob := publisher.blob(t, 3, 0, 800000000, validator.manifest(t, 1, "sp5fghtJtpUorTwvof1NpDXAzNwf5"))
+	p := &PublishedList{
+		PublicKey: publisher.publicKey(),
+		Manifest:  publisher.manifest,
+		Blob:      blob.Blob,
+		Signature: blob.Signature,
+		Version:   1,
+	}
+
+	list, err := p.Current(publisher.publicKey(), rippleTime(799999999))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if list.Sequence != 3 || !list.Effective.IsZero() || !list.Expiration.Equal(rippleTime(800000000)) {
+		t.Errorf("got list %d effective %v expiring %v", list.Sequence, list.Effective, list.Expiration)
+	}
+	if list.PublisherKey != publisher.masterKey(t) {
+		t.Errorf("published by %s", list.PublisherKey)
+	}
+	if !list.Contains(validator.masterKey(t)) || len(list.MasterKeys()) != 1 {
+		t.Errorf("listed %v", list.MasterKeys())
+	}
+	if _, err := p.Current(publisher.publicKey(), rippleTime(800000000)); err == nil {
+		t.Error("an expired list is current")
+	}
+
+	// The manifests in the list resolve the validators' signing keys.
+	registry := NewRegistry()
+	if err := list.ApplyManifests(registry); err != nil {
+		t.Fatal(err)
+	}
+	if err := list.ApplyManifests(registry); err != nil {
+		t.Errorf("applying the same manifests again: %v", err)
+	}
+	_, signingKey := ephemeralKey(t, "sp5fghtJtpUorTwvof1NpDXAzNwf5")
+	if master, ok := registry.MasterKey(signingKey); !ok || master != validator.masterKey(t) {
+		t.Errorf("MasterKey(%s) = %s %v", signingKey, master, ok)
+	}
+
+	tampered := *p
+	data, _ := base64.StdEncoding.DecodeString(p.Blob)
+	data[len(data)-2] ^= 1
+	tampered.Blob = base64.StdEncoding.EncodeToString(data)
+	if _, err := tampered.Verify(publisher.publicKey()); !errors.Is(err, ErrInvalidSignature) {
+		t.Errorf("tampered blob: got %v, want %v", err, ErrInvalidSignature)
+	}
+
+	if _, err := p.Verify(validator.masterPublic); err == nil {
+		t.Error("verified a list against another publisher key")
+	}
+	forged := *p
+	forged.PublicKey = validator.masterPublic
+	forged.Manifest, _ = validator.manifest(t, 1, "sp5fghtJtpUorTwvof1NpDXAzNwf5").Encode()
+	if _, err := forged.Verify(publisher.publicKey()); err == nil {
+		t.Error("verified a list signed by another publisher")
+	}
+	revoked := *p
+	revoked.Manifest, _ = publisher.testValidator.manifest(t, RevokedSequence, "").Encode()
+	if _, err := revoked.Verify(publisher.publicKey()); err == nil {
+		t.Error("verified a list of a revoked publisher")
+	}
+}
+
+func TestPublishedListV2(t *testing.T) {
+	publisher := newListPublisher(t, 1, "sp5fghtJtpUorTwvof1NpDXAzNwf5")
+	validator := newTestValidator(t, "sEdSKaCy2JT7JaM7v95H9SxkhP9wS2r").manifest(t, 1, "sp5fghtJtpUorTwvof1NpDXAzNwf5")
+	manifest := publisher.manifest
+	current := publisher.blob(t, 1, 0, 800000000, validator)
+
+	// The next list takes effect before the current one expires and is
+	// signed with a key the publisher has since rotated to.
+	publisher.rotate(t, 2, "snoPBrXtMeMyMHUVTgbuqAfg1SUTb")
+	next := publisher.blob(t, 2, 790000000, 810000000, validator)
+	next.Manifest = publisher.manifest
+
+	p := &PublishedList{
+		PublicKey: publisher.publicKey(),
+		Manifest:  manifest,
+		BlobsV2:   []ListBlob{next, current},
+		Version:   2,
+	}
+	// Serve the list as a publisher site does.
+	data, err := json.Marshal(p)
+	if err != nil {
+		t.Fatal(err)
+	}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write(data)
+	}))
+	defer server.Close()
+	fetched, err := FetchPublishedList(context.Background(), server.URL)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	lists, err := fetched.Verify(publisher.publicKey())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(lists) != 2 || lists[0].Sequence != 1 || lists[1].Sequence != 2 {
+		t.Fatalf("got %d lists", len(lists))
+	}
+	for _, test := range []struct {
+		now      int64
+		sequence uint32
+	}{
+		{789999999, 1},
+		{790000000, 2},
+		{809999999, 2},
+		{810000000, 0},
+	} {
+		list, err := fetched.Current(publisher.publicKey(), rippleTime(test.now))
+		if test.sequence == 0 {
+			if err == nil {
+				t.Errorf("%d: list %d is current after every list expired", test.now, list.Sequence)
+			}
+			continue
+		}
+		if err != nil {
+			t.Errorf("%d: %v", test.now, err)
+		} else if list.Sequence != test.sequence {
+			t.Errorf("%d: list %d is current, want %d", test.now, list.Sequence, test.sequence)
+		}
+	}
+
+	// Without its own manifest, the next blob is checked against the key
+	// of the list's manifest, which did not sign it.
+	missing := *fetched
+	missing.BlobsV2 = []ListBlob{fetched.BlobsV2[0], fetched.BlobsV2[1]}
+	missing.BlobsV2[0].Manifest = ""
+	if _, err := missing.Verify(publisher.publicKey()); !errors.Is(err, ErrInvalidSignature) {
+		t.Errorf("blob without its manifest: got %v, want %v", err, ErrInvalidSignature)
+	}
+
+	path := filepath.Join(t.TempDir(), "vl.json")
+	if err := os.WriteFile(path, data, 0o600); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := LoadPublishedList(path); err != nil {
+		t.Error(err)
+	}
+	if _, err := ParsePublishedList([]byte(`{"version": 2}`)); err == nil {
+		t.Error("parsed a list without a publisher")
+	}
+}
+
+func TestListQuorum(t *testing.T) {
+	for _, test := range []struct {
+		validators, quorum int
+	}{
+		{0, 0},
+		{1, 1},
+		{4, 4},
+		{5, 4},
+		{6, 5},
+		{10, 8},
+		{11, 9},
+		{35, 28},
+		{36, 29},
+	} {
+		l := &List{Validators: make([]ListedValidator, test.validators)}
+		if got := l.Quorum(); got != test.quorum {
+			t.Errorf("%d validators: quorum %d, want %d", test.validators, got, test.quorum)
+		}
+	}
+}
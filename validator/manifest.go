@@ -0,0 +1,183 @@
+package validator
+
+import (
+	"encoding/base64"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/xrpscan/xrpl-go/addresscodec"
+	"github.com/xrpscan/xrpl-go/binarycodec"
+	"github.com/xrpscan/xrpl-go/keypairs"
+	"github.com/xrpscan/xrpl-go/models"
+)
+
+// RevokedSequence is the sequence of a manifest that permanently revokes
+// its master key.
+const RevokedSequence uint32 = 0xFFFFFFFF
+
+// Manifest binds a validator's master key to the ephemeral key it signs
+// validations with. Keys are base58 node public keys and signatures are
+// uppercase hex.
+// https://xrpl.org/docs/references/http-websocket-apis/public-api-methods/server-info-methods/manifest
+type Manifest struct {
+	MasterKey       string `json:"master_key"`
+	SigningKey      string `json:"signing_key,omitempty"`
+	Sequence        uint32 `json:"seq"`
+	Domain          string `json:"domain,omitempty"`
+	Signature       string `json:"signature,omitempty"`
+	MasterSignature string `json:"master_signature"`
+}
+
+// DecodeManifest decodes a base64 encoded manifest, as returned by the
+// manifest method and published in validator lists.
+func DecodeManifest(encoded string) (*Manifest, error) {
+	b, err := base64.StdEncoding.DecodeString(encoded)
+	if err != nil {
+		return nil, err
+	}
+	fields, err := binarycodec.Decode(hexUpper(b))
+	if err != nil {
+		return nil, err
+	}
+
+	m := &Manifest{}
+	if m.MasterKey, err = nodePublic(fields["PublicKey"]); err != nil {
+		return nil, fmt.Errorf("manifest master key: %w", err)
+	}
+	if key, ok := fields["SigningPubKey"]; ok {
+		if m.SigningKey, err = nodePublic(key); err != nil {
+			return nil, fmt.Errorf("manifest signing key: %w", err)
+		}
+	}
+	sequence, ok := fields["Sequence"].(uint32)
+	if !ok {
+		return nil, errors.New("manifest has no sequence")
+	}
+	m.Sequence = sequence
+	if domain, ok := fields["Domain"].(string); ok {
+		b, err := hex.DecodeString(domain)
+		if err != nil {
+			return nil, err
+		}
+		m.Domain = string(b)
+	}
+	m.Signature, _ = fields["Signature"].(string)
+	m.MasterSignature, _ = fields["MasterSignature"].(string)
+	return m, nil
+}
+
+// ManifestFromStream converts a manifest stream message.
+func ManifestFromStream(msg models.ManifestStream) *Manifest {
+	return &Manifest{
+		MasterKey:       msg.MasterKey,
+		SigningKey:      msg.SigningKey,
+		Sequence:        msg.Seq,
+		Domain:          msg.Domain,
+		Signature:       strings.ToUpper(msg.Signature),
+		MasterSignature: strings.ToUpper(msg.MasterSignature),
+	}
+}
+
+// Revoked reports whether the manifest revokes its master key.
+func (m *Manifest) Revoked() bool {
+	return m.Sequence == RevokedSequence
+}
+
+// Encode serializes the manifest to base64.
+func (m *Manifest) Encode() (string, error) {
+	fields, err := m.fields()
+	if err != nil {
+		return "", err
+	}
+	if m.Signature != "" {
+		fields["Signature"] = m.Signature
+	}
+	fields["MasterSignature"] = m.MasterSignature
+	encoded, err := binarycodec.Encode(fields)
+	if err != nil {
+		return "", err
+	}
+	b, err := hex.DecodeString(encoded)
+	if err != nil {
+		return "", err
+	}
+	return base64.StdEncoding.EncodeToString(b), nil
+}
+
+// Verify checks the master signature and, unless the manifest is a
+// revocation, the signature by the ephemeral key.
+func (m *Manifest) Verify() error {
+	fields, err := m.fields()
+	if err != nil {
+		return err
+	}
+	signing, err := binarycodec.EncodeSigningFields(fields)
+	if err != nil {
+		return err
+	}
+	b, err := hex.DecodeString(signing)
+	if err != nil {
+		return err
+	}
+	message := append(append([]byte{}, binarycodec.HashPrefixManifest...), b...)
+
+	if !keypairs.Verify(message, m.MasterSignature, fields["PublicKey"].(string)) {
+		return fmt.Errorf("manifest master signature: %w", ErrInvalidSignature)
+	}
+	if m.Revoked() {
+		return nil
+	}
+	if m.SigningKey == "" || m.Signature == "" {
+		return errors.New("manifest has no signing key")
+	}
+	if m.SigningKey == m.MasterKey {
+		return errors.New("manifest signing key is its master key")
+	}
+	if !keypairs.Verify(message, m.Signature, fields["SigningPubKey"].(string)) {
+		return fmt.Errorf("manifest signature: %w", ErrInvalidSignature)
+	}
+	return nil
+}
+
+// fields returns the manifest in the codec's JSON form, without signatures.
+func (m *Manifest) fields() (map[string]interface{}, error) {
+	masterKey, err := addresscodec.DecodeNodePublic(m.MasterKey)
+	if err != nil {
+		return nil, err
+	}
+	fields := map[string]interface{}{
+		"PublicKey": hexUpper(masterKey),
+		"Sequence":  m.Sequence,
+	}
+	if m.SigningKey != "" {
+		signingKey, err := addresscodec.DecodeNodePublic(m.SigningKey)
+		if err != nil {
+			return nil, err
+		}
+		fields["SigningPubKey"] = hexUpper(signingKey)
+	}
+	if m.Domain != "" {
+		fields["Domain"] = hexUpper([]byte(m.Domain))
+	}
+	return fields, nil
+}
+
+// nodePublic converts a hex encoded key in decoded manifest fields to a
+// base58 node public key.
+func nodePublic(value interface{}) (string, error) {
+	s, ok := value.(string)
+	if !ok {
+		return "", errors.New("missing key")
+	}
+	key, err := hex.DecodeString(s)
+	if err != nil {
+		return "", err
+	}
+	return addresscodec.EncodeNodePublic(key)
+}
+
+func hexUpper(b []byte) string {
+	return strings.ToUpper(hex.EncodeToString(b))
+}
@@ -0,0 +1,203 @@
+package validator
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/xrpscan/xrpl-go/keypairs"
+	"github.com/xrpscan/xrpl-go/models"
+)
+
+// realManifest is the mainnet manifest in the example response of the
+// manifest method on xrpl.org.
+const realManifest = "JAAAAAFxIe3AkJgOyqs3y+UuiAI27Ff3Mrfbt8e7mjdo06bnGEp5XnMhAhRmvCZmWZXlwShVE9qXs2AVCvhVuA/WGYkTX/vVGBGwdkYwRAIgGnYpIGufURojN2cTXakAM7Vwa0GR7o3osdVlZShroXQCIH9R/Lx1v9rdb4YY2n5nrxdnhSSof3U6V/wIHJmeao5ucBJA9D1iAMo7YFCpb245N3Czc0L1R2Xac0YwQ6XdGT+cZ7yw2n8JbdC3hH8Xu9OUqc867Ee6JmlXtyDHzBdY/hdJCQ=="
+
+const (
+	realMasterKey  = "nHUFE9prPXPrHcG3SkwP1UzAQbSphqyQkQK9ATXLZsfkezhhda3p"
+	realSigningKey = "n9J67zk4B7GpbQV5jRQntbgdKf7TW6894QuG7qq1rE5gvjCu6snA"
+)
+
+func mustDecodeManifest(t *testing.T, encoded string) *Manifest {
+	t.Helper()
+	m, err := DecodeManifest(encoded)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return m
+}
+
+func TestDecodeManifest(t *testing.T) {
+	m := mustDecodeManifest(t, realManifest)
+	if m.MasterKey != realMasterKey || m.SigningKey != realSigningKey || m.Sequence != 1 || m.Domain != "" {
+		t.Errorf("decoded %+v", m)
+	}
+	if err := m.Verify(); err != nil {
+		t.Fatal(err)
+	}
+	encoded, err := m.Encode()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if encoded != realManifest {
+		t.Errorf("Encode:\n got %s\nwant %s", encoded, realManifest)
+	}
+
+	streamed := ManifestFromStream(models.ManifestStream{
+		MasterKey:       m.MasterKey,
+		SigningKey:      m.SigningKey,
+		Seq:             m.Sequence,
+		Signature:       m.Signature,
+		MasterSignature: m.MasterSignature,
+	})
+	if !reflect.DeepEqual(streamed, m) {
+		t.Errorf("ManifestFromStream: got %+v, want %+v", streamed, m)
+	}
+
+	tampered := *m
+	tampered.Sequence++
+	if err := tampered.Verify(); !errors.Is(err, ErrInvalidSignature) {
+		t.Errorf("tampered sequence: got %v, want %v", err, ErrInvalidSignature)
+	}
+	tampered = *m
+	tampered.Signature = m.MasterSignature
+	if err := tampered.Verify(); !errors.Is(err, ErrInvalidSignature) {
+		t.Errorf("swapped signature: got %v, want %v", err, ErrInvalidSignature)
+	}
+}
+
+func TestManifestEncode(t *testing.T) {
+	tv := newTestValidator(t, "sEdSKaCy2JT7JaM7v95H9SxkhP9wS2r")
+	signingPrivate, signingKey := ephemeralKey(t, "sp5fghtJtpUorTwvof1NpDXAzNwf5")
+	m := &Manifest{MasterKey: tv.masterKey(t), SigningKey: signingKey, Sequence: 7, Domain: "example.com"}
+	signManifest(t, m, tv.masterPrivate, signingPrivate)
+	if err := m.Verify(); err != nil {
+		t.Fatal(err)
+	}
+
+	for _, m := range []*Manifest{m, tv.manifest(t, RevokedSequence, "")} {
+		encoded, err := m.Encode()
+		if err != nil {
+			t.Fatal(err)
+		}
+		decoded := mustDecodeManifest(t, encoded)
+		if !reflect.DeepEqual(decoded, m) {
+			t.Errorf("round trip: got %+v, want %+v", decoded, m)
+		}
+	}
+}
+
+func TestRegistry(t *testing.T) {
+	const signingSeed, nextSigningSeed = "sp5fghtJtpUorTwvof1NpDXAzNwf5", "snoPBrXtMeMyMHUVTgbuqAfg1SUTb"
+	tv := newTestValidator(t, "sEdSKaCy2JT7JaM7v95H9SxkhP9wS2r")
+	_, signingKey := ephemeralKey(t, signingSeed)
+	_, nextSigningKey := ephemeralKey(t, nextSigningSeed)
+
+	r := NewRegistry()
+	if err := r.Apply(mustDecodeManifest(t, realManifest)); err != nil {
+		t.Fatal(err)
+	}
+	if master, ok := r.MasterKey(realSigningKey); !ok || master != realMasterKey {
+		t.Errorf("MasterKey(%s) = %s %v", realSigningKey, master, ok)
+	}
+	if err := r.Apply(tv.manifest(t, 2, signingSeed)); err != nil {
+		t.Fatal(err)
+	}
+
+	for _, sequence := range []uint32{1, 2} {
+		if err := r.Apply(tv.manifest(t, sequence, nextSigningSeed)); !errors.Is(err, ErrStaleManifest) {
+			t.Errorf("sequence %d: got %v, want %v", sequence, err, ErrStaleManifest)
+		}
+	}
+	if key, _ := r.SigningKey(tv.masterKey(t)); key != signingKey {
+		t.Errorf("stale manifest replaced signing key with %s", key)
+	}
+
+	if err := r.Apply(tv.manifest(t, 3, nextSigningSeed)); err != nil {
+		t.Fatal(err)
+	}
+	if _, ok := r.MasterKey(signingKey); ok {
+		t.Error("replaced signing key still resolves")
+	}
+	if master, ok := r.MasterKey(nextSigningKey); !ok || master != tv.masterKey(t) {
+		t.Errorf("MasterKey(%s) = %s %v", nextSigningKey, master, ok)
+	}
+
+	badSignature := tv.manifest(t, 4, signingSeed)
+	badSignature.Signature = badSignature.MasterSignature
+	if err := r.Apply(badSignature); !errors.Is(err, ErrInvalidSignature) {
+		t.Errorf("bad signature: got %v, want %v", err, ErrInvalidSignature)
+	}
+
+	// Signing keys may not be shared, nor be or become master keys.
+	other := newTestValidator(t, "sEdSuqBPSQaood2DmNYVkwWTn1oQTj2")
+	if err := r.Apply(other.manifest(t, 1, nextSigningSeed)); err == nil || !strings.Contains(err.Error(), "in use by") {
+		t.Errorf("signing key in use by another validator: got %v", err)
+	}
+	masterAsSigning := &Manifest{MasterKey: other.masterKey(t), SigningKey: tv.masterKey(t), Sequence: 1}
+	signManifest(t, masterAsSigning, other.masterPrivate, tv.masterPrivate)
+	if err := r.Apply(masterAsSigning); err == nil || !strings.Contains(err.Error(), "is a master key") {
+		t.Errorf("master key as signing key: got %v", err)
+	}
+	private, public, err := keypairs.DeriveValidatorKeypair(nextSigningSeed)
+	if err != nil {
+		t.Fatal(err)
+	}
+	signingAsMaster := &testValidator{private, public}
+	if err := r.Apply(signingAsMaster.manifest(t, 1, signingSeed)); err == nil || !strings.Contains(err.Error(), "in use as a signing key") {
+		t.Errorf("signing key as master key: got %v", err)
+	}
+
+	// Revocation forgets the signing key for good.
+	if err := r.Apply(tv.manifest(t, RevokedSequence, "")); err != nil {
+		t.Fatal(err)
+	}
+	if !r.Revoked(tv.masterKey(t)) {
+		t.Error("master key not revoked")
+	}
+	if _, ok := r.MasterKey(nextSigningKey); ok {
+		t.Error("revoked signing key still resolves")
+	}
+	if _, ok := r.SigningKey(tv.masterKey(t)); ok {
+		t.Error("revoked master key has a signing key")
+	}
+	if err := r.Apply(tv.manifest(t, RevokedSequence, signingSeed)); !errors.Is(err, ErrStaleManifest) {
+		t.Errorf("after revocation: got %v, want %v", err, ErrStaleManifest)
+	}
+
+	// Save and load round trip, verifying every manifest again.
+	path := filepath.Join(t.TempDir(), "manifests.json")
+	if err := r.Save(path); err != nil {
+		t.Fatal(err)
+	}
+	loaded, err := LoadRegistry(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(loaded.Manifests(), r.Manifests()) {
+		t.Errorf("loaded %+v, want %+v", loaded.Manifests(), r.Manifests())
+	}
+	if master, ok := loaded.MasterKey(realSigningKey); !ok || master != realMasterKey {
+		t.Errorf("loaded MasterKey(%s) = %s %v", realSigningKey, master, ok)
+	}
+	if !loaded.Revoked(tv.masterKey(t)) {
+		t.Error("loaded registry forgot the revocation")
+	}
+	entries, err := os.ReadDir(filepath.Dir(path))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(entries) != 1 {
+		t.Errorf("Save left %d files behind", len(entries)-1)
+	}
+
+	if err := os.WriteFile(path, []byte(`["`+realManifest[:len(realManifest)-8]+`"]`), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := LoadRegistry(path); err == nil {
+		t.Error("loaded a truncated manifest")
+	}
+}
@@ -0,0 +1,174 @@
+package validator
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"sync"
+)
+
+// ErrStaleManifest is returned when a manifest does not have a higher
+// sequence than the one already known for its master key.
+var ErrStaleManifest = errors.New("stale manifest")
+
+// Registry tracks the latest manifest of each validator, and with it the
+// ephemeral key each master key currently signs with. It implements
+// MasterKeyResolver and is safe for concurrent use.
+type Registry struct {
+	mutex       sync.RWMutex
+	manifests   map[string]*Manifest // by master key
+	signingKeys map[string]string    // signing key to master key
+}
+
+// NewRegistry returns an empty registry.
+func NewRegistry() *Registry {
+	return &Registry{
+		manifests:   make(map[string]*Manifest),
+		signingKeys: make(map[string]string),
+	}
+}
+
+// Apply verifies a manifest and makes it current for its master key. Once
+// a master key is revoked, its signing key is forgotten and no further
+// manifests are accepted for it.
+func (r *Registry) Apply(m *Manifest) error {
+	if err := m.Verify(); err != nil {
+		return err
+	}
+
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+	current, ok := r.manifests[m.MasterKey]
+	if ok && m.Sequence <= current.Sequence {
+		return ErrStaleManifest
+	}
+	if _, ok := r.manifests[m.SigningKey]; ok {
+		return fmt.Errorf("manifest signing key %s is a master key", m.SigningKey)
+	}
+	if master, ok := r.signingKeys[m.SigningKey]; ok && master != m.MasterKey {
+		return fmt.Errorf("manifest signing key %s is in use by %s", m.SigningKey, master)
+	}
+	if _, ok := r.signingKeys[m.MasterKey]; ok {
+		return fmt.Errorf("manifest master key %s is in use as a signing key", m.MasterKey)
+	}
+
+	if ok {
+		delete(r.signingKeys, current.SigningKey)
+	}
+	r.manifests[m.MasterKey] = m
+	if !m.Revoked() {
+		r.signingKeys[m.SigningKey] = m.MasterKey
+	}
+	return nil
+}
+
+// MasterKey returns the master key whose current manifest delegates to
+// signingKey.
+func (r *Registry) MasterKey(signingKey string) (string, bool) {
+	r.mutex.RLock()
+	defer r.mutex.RUnlock()
+	masterKey, ok := r.signingKeys[signingKey]
+	return masterKey, ok
+}
+
+// SigningKey returns the current signing key of a master key. Revoked and
+// unknown master keys have none.
+func (r *Registry) SigningKey(masterKey string) (string, bool) {
+	r.mutex.RLock()
+	defer r.mutex.RUnlock()
+	m, ok := r.manifests[masterKey]
+	if !ok || m.Revoked() {
+		return "", false
+	}
+	return m.SigningKey, true
+}
+
+// Manifest returns the current manifest of a master key.
+func (r *Registry) Manifest(masterKey string) (*Manifest, bool) {
+	r.mutex.RLock()
+	defer r.mutex.RUnlock()
+	m, ok := r.manifests[masterKey]
+	return m, ok
+}
+
+// Revoked reports whether a master key has been revoked.
+func (r *Registry) Revoked(masterKey string) bool {
+	r.mutex.RLock()
+	defer r.mutex.RUnlock()
+	m, ok := r.manifests[masterKey]
+	return ok && m.Revoked()
+}
+
+// Manifests returns the current manifest of every known master key,
+// ordered by master key.
+func (r *Registry) Manifests() []*Manifest {
+	r.mutex.RLock()
+	defer r.mutex.RUnlock()
+	manifests := make([]*Manifest, 0, len(r.manifests))
+	for _, m := range r.manifests {
+		manifests = append(manifests, m)
+	}
+	sort.Slice(manifests, func(i, j int) bool {
+		return manifests[i].MasterKey < manifests[j].MasterKey
+	})
+	return manifests
+}
+
+// Save writes the registry to path as a JSON array of base64 encoded
+// manifests. The file is replaced atomically.
+func (r *Registry) Save(path string) error {
+	manifests := r.Manifests()
+	encoded := make([]string, 0, len(manifests))
+	for _, m := range manifests {
+		e, err := m.Encode()
+		if err != nil {
+			return err
+		}
+		encoded = append(encoded, e)
+	}
+	data, err := json.MarshalIndent(encoded, "", "  ")
+	if err != nil {
+		return err
+	}
+
+	tmp, err := os.CreateTemp(filepath.Dir(path), filepath.Base(path)+".*")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(tmp.Name())
+	if _, err := tmp.Write(data); err != nil {
+		tmp.Close()
+		return err
+	}
+	if err := tmp.Close(); err != nil {
+		return err
+	}
+	return os.Rename(tmp.Name(), path)
+}
+
+// LoadRegistry reads a registry written by Save. Every manifest is verified
+// again as it is loaded.
+func LoadRegistry(path string) (*Registry, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	var encoded []string
+	if err := json.Unmarshal(data, &encoded); err != nil {
+		return nil, err
+	}
+	r := NewRegistry()
+	for _, e := range encoded {
+		m, err := DecodeManifest(e)
+		if err != nil {
+			return nil, err
+		}
+		if err := r.Apply(m); err != nil {
+			return nil, fmt.Errorf("manifest for %s: %w", m.MasterKey, err)
+		}
+	}
+	return r, nil
+}
@@ -0,0 +1,164 @@
+// Package validator decodes and verifies the messages validators publish:
+// validations of ledgers and the manifests that bind their master keys to
+// the ephemeral keys they sign with.
+package validator
+
+import (
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/xrpscan/xrpl-go/addresscodec"
+	"github.com/xrpscan/xrpl-go/binarycodec"
+	"github.com/xrpscan/xrpl-go/keypairs"
+	"github.com/xrpscan/xrpl-go/models"
+)
+
+// Validation flags
+const (
+	// Set when the validation is for a full, not a partial, validation.
+	ValidationFlagFull uint32 = 0x00000001
+	// Set when the signature is required to be fully canonical.
+	ValidationFlagFullyCanonicalSig uint32 = 0x80000000
+)
+
+var (
+	// ErrInvalidSignature is returned when a signature does not verify.
+	ErrInvalidSignature = errors.New("invalid signature")
+
+	// ErrUnknownSigningKey is returned when no master key is known for the
+	// key that signed a validation.
+	ErrUnknownSigningKey = errors.New("unknown signing key")
+)
+
+// Validation is a decoded STValidation, the data field of a validation
+// stream message. Hashes and keys are uppercase hex. UInt64 fields are hex
+// strings, as in the codec's JSON form.
+// https://xrpl.org/docs/references/http-websocket-apis/public-api-methods/subscription-methods/subscribe#validations-stream
+type Validation struct {
+	Flags                 uint32   `json:"Flags"`
+	LedgerSequence        uint32   `json:"LedgerSequence"`
+	SigningTime           uint32   `json:"SigningTime"`
+	LedgerHash            string   `json:"LedgerHash"`
+	ConsensusHash         string   `json:"ConsensusHash,omitempty"`
+	ValidatedHash         string   `json:"ValidatedHash,omitempty"`
+	Cookie                string   `json:"Cookie,omitempty"`
+	ServerVersion         string   `json:"ServerVersion,omitempty"`
+	LoadFee               uint32   `json:"LoadFee,omitempty"`
+	BaseFee               string   `json:"BaseFee,omitempty"`
+	ReserveBase           uint32   `json:"ReserveBase,omitempty"`
+	ReserveIncrement      uint32   `json:"ReserveIncrement,omitempty"`
+	BaseFeeDrops          string   `json:"BaseFeeDrops,omitempty"`
+	ReserveBaseDrops      string   `json:"ReserveBaseDrops,omitempty"`
+	ReserveIncrementDrops string   `json:"ReserveIncrementDrops,omitempty"`
+	Amendments            []string `json:"Amendments,omitempty"`
+	SigningPubKey         string   `json:"SigningPubKey"`
+	Signature             string   `json:"Signature"`
+
+	fields map[string]interface{}
+}
+
+// MasterKeyResolver maps the ephemeral key a validator signs with to its
+// master key. Both are base58 node public keys.
+type MasterKeyResolver interface {
+	MasterKey(signingKey string) (string, bool)
+}
+
+// DecodeValidation decodes a hex encoded STValidation.
+func DecodeValidation(data string) (*Validation, error) {
+	fields, err := binarycodec.Decode(data)
+	if err != nil {
+		return nil, err
+	}
+	b, err := json.Marshal(fields)
+	if err != nil {
+		return nil, err
+	}
+	v := &Validation{}
+	if err := json.Unmarshal(b, v); err != nil {
+		return nil, err
+	}
+	if v.SigningPubKey == "" || v.Signature == "" {
+		return nil, errors.New("validation is not signed")
+	}
+	v.fields = fields
+	return v, nil
+}
+
+// ValidationFromStream decodes the data of a validation stream message and
+// checks that it agrees with the fields the server reported alongside it.
+func ValidationFromStream(msg models.ValidationStream) (*Validation, error) {
+	v, err := DecodeValidation(msg.Data)
+	if err != nil {
+		return nil, err
+	}
+	signingKey, err := v.SigningKey()
+	if err != nil {
+		return nil, err
+	}
+	if msg.ValidationPublicKey != "" && msg.ValidationPublicKey != signingKey {
+		return nil, fmt.Errorf("validation_public_key %s does not match signing key %s", msg.ValidationPublicKey, signingKey)
+	}
+	if msg.Signature != "" && !strings.EqualFold(msg.Signature, v.Signature) {
+		return nil, errors.New("signature does not match validation data")
+	}
+	if msg.LedgerHash != "" && !strings.EqualFold(msg.LedgerHash, v.LedgerHash) {
+		return nil, errors.New("ledger_hash does not match validation data")
+	}
+	return v, nil
+}
+
+// Full reports whether this is a full validation.
+func (v *Validation) Full() bool {
+	return v.Flags&ValidationFlagFull != 0
+}
+
+// SigningKey returns the key that signed the validation as a base58 node
+// public key.
+func (v *Validation) SigningKey() (string, error) {
+	key, err := hex.DecodeString(v.SigningPubKey)
+	if err != nil {
+		return "", err
+	}
+	return addresscodec.EncodeNodePublic(key)
+}
+
+// VerifySignature checks the signature of the validation against its
+// SigningPubKey. The validation must have been decoded from its data.
+func (v *Validation) VerifySignature() error {
+	if v.fields == nil {
+		return errors.New("validation was not decoded from data")
+	}
+	signing, err := binarycodec.EncodeSigningFields(v.fields)
+	if err != nil {
+		return err
+	}
+	b, err := hex.DecodeString(signing)
+	if err != nil {
+		return err
+	}
+	message := append(append([]byte{}, binarycodec.HashPrefixValidation...), b...)
+	if !keypairs.Verify(message, v.Signature, v.SigningPubKey) {
+		return ErrInvalidSignature
+	}
+	return nil
+}
+
+// Verify checks the signature of the validation and returns the master key
+// of the validator that signed it, as a base58 node public key.
+func (v *Validation) Verify(keys MasterKeyResolver) (string, error) {
+	if err := v.VerifySignature(); err != nil {
+		return "", err
+	}
+	signingKey, err := v.SigningKey()
+	if err != nil {
+		return "", err
+	}
+	masterKey, ok := keys.MasterKey(signingKey)
+	if !ok {
+		return "", ErrUnknownSigningKey
+	}
+	return masterKey, nil
+}
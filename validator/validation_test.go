@@ -0,0 +1,286 @@
+package validator
+
+import (
+	"encoding/hex"
+	"errors"
+	"testing"
+
+	"github.com/xrpscan/xrpl-go/addresscodec"
+	"github.com/xrpscan/xrpl-go/binarycodec"
+	"github.com/xrpscan/xrpl-go/keypairs"
+	"github.com/xrpscan/xrpl-go/models"
+)
+
+// Two mainnet validations from 2014 (ledgers 6951500 and 6951734), as captured
+// in github.com/rubblelabs/ripple testing/testing.go (commit 6816ca31ba51).
+// They predate manifests: the validators signed with their master keys.
+var realValidations = []struct {
+	data           string
+	signingKey     string
+	ledgerSequence uint32
+	ledgerHash     string
+}{
+	{
+		data:           "228000000026006A124C291B1DBFA6511A8194A501C8C9AC779A96495365D596371C09636E63F62BB0B4B81CF1239BAF732103280B1651DD14F4A56D834ACBE6637645032D871D0BDFF3EC0B8335A021EEC6C276473045022100FEFADD500D6B9E0086885943EE299378FD7A46E2780211468141B798B8756816022006F462B93BDA3D105F559B3B1824854054BD7BE346D9EC70EFEF13558E834992",
+		signingKey:     "n9L81uNCaPgtUJfaHh89gmdvXKAmSt5Gdsw2g1iPWaPkAHW5Nm4C",
+		ledgerSequence: 6951500,
+		ledgerHash:     "1A8194A501C8C9AC779A96495365D596371C09636E63F62BB0B4B81CF1239BAF",
+	},
+	{
+		data:           "228000000026006A1336291B1DC46751B1EF9D91B9102381B93C8E38FCDA8ED59543AF44AC72BAF0A613EAE76F586E2F732102ACAA0A6AB8C6BAD6495DF58C1A5ADB9BC3054304743DEEA5F68B6B5560CCD15E76463044022071F94FAEEB5E72DA252C14C2AF28F5C8EB7C411F65C9BBA472943ACF66E23DD40220204E81EE4826776FC438D0B074A8A7AD4823AFDEB24A3B6B15BEB64D304D9E52",
+		signingKey:     "n9KiYM9CgngLvtRCQHZwgC2gjpdaZcCcbt3VboxiNFcKuwFVujzS",
+		ledgerSequence: 6951734,
+		ledgerHash:     "B1EF9D91B9102381B93C8E38FCDA8ED59543AF44AC72BAF0A613EAE76F586E2F",
+	},
+}
+
+// testValidator is a made-up validator: an ed25519 master key that
+// delegates to secp256k1 ephemeral keys through manifests it signs.
+type testValidator struct {
+	masterPrivate, masterPublic string
+}
+
+func newTestValidator(t *testing.T, seed string) *testValidator {
+	t.Helper()
+	private, public, err := keypairs.DeriveKeypair(seed)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return &testValidator{private, public}
+}
+
+func (tv *testValidator) masterKey(t *testing.T) string {
+	return nodePublicHex(t, tv.masterPublic)
+}
+
+// ephemeralKey derives a signing key, returning its private key and base58
+// node public key.
+func ephemeralKey(t *testing.T, seed string) (string, string) {
+	t.Helper()
+	private, public, err := keypairs.DeriveValidatorKeypair(seed)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return private, nodePublicHex(t, public)
+}
+
+// manifest signs a manifest delegating to the ephemeral key of
+// signingSeed, or revokes the master key if signingSeed is empty.
+func (tv *testValidator) manifest(t *testing.T, sequence uint32, signingSeed string) *Manifest {
+	t.Helper()
+	m := &Manifest{MasterKey: tv.masterKey(t), Sequence: sequence}
+	var signingPrivate string
+	if signingSeed != "" {
+		signingPrivate, m.SigningKey = ephemeralKey(t, signingSeed)
+	}
+	signManifest(t, m, tv.masterPrivate, signingPrivate)
+	return m
+}
+
+// signManifest sets the signatures of m, leaving Signature empty if
+// signingPrivate is.
+func signManifest(t *testing.T, m *Manifest, masterPrivate, signingPrivate string) {
+	t.Helper()
+	fields, err := m.fields()
+	if err != nil {
+		t.Fatal(err)
+	}
+	message := signingMessage(t, binarycodec.HashPrefixManifest, fields)
+	if m.MasterSignature, err = keypairs.Sign(message, masterPrivate); err != nil {
+		t.Fatal(err)
+	}
+	if signingPrivate != "" {
+		if m.Signature, err = keypairs.Sign(message, signingPrivate); err != nil {
+			t.Fatal(err)
+		}
+	}
+}
+
+// validation returns a full validation of ledgerHash signed with the
+// ephemeral key of signingSeed, hex encoded.
+func validation(t *testing.T, signingSeed string, ledgerSequence uint32, ledgerHash string) string {
+	t.Helper()
+	private, public, err := keypairs.DeriveValidatorKeypair(signingSeed)
+	if err != nil {
+		t.Fatal(err)
+	}
+	fields := map[string]interface{}{
+		"Flags":          ValidationFlagFullyCanonicalSig | ValidationFlagFull,
+		"LedgerSequence": ledgerSequence,
+		"SigningTime":    uint32(800000000),
+		"LedgerHash":     ledgerHash,
+		"SigningPubKey":  public,
+	}
+	message := signingMessage(t, binarycodec.HashPrefixValidation, fields)
+	if fields["Signature"], err = keypairs.Sign(message, private); err != nil {
+		t.Fatal(err)
+	}
+	data, err := binarycodec.Encode(fields)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return data
+}
+
+func signingMessage(t *testing.T, prefix []byte, fields map[string]interface{}) []byte {
+	t.Helper()
+	signing, err := binarycodec.EncodeSigningFields(fields)
+	if err != nil {
+		t.Fatal(err)
+	}
+	b, err := hex.DecodeString(signing)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return append(append([]byte{}, prefix...), b...)
+}
+
+func nodePublicHex(t *testing.T, public string) string {
+	t.Helper()
+	key, err := hex.DecodeString(public)
+	if err != nil {
+		t.Fatal(err)
+	}
+	encoded, err := addresscodec.EncodeNodePublic(key)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return encoded
+}
+
+// tamper flips the last bit of the LedgerHash of hex encoded validation
+// data.
+func tamper(t *testing.T, data, ledgerHash string) string {
+	t.Helper()
+	b, err := hex.DecodeString(data)
+	if err != nil {
+		t.Fatal(err)
+	}
+	hash, _ := hex.DecodeString(ledgerHash)
+	for i := 0; i+len(hash) <= len(b); i++ {
+		if string(b[i:i+len(hash)]) == string(hash) {
+			b[i+len(hash)-1] ^= 1
+			return hexUpper(b)
+		}
+	}
+	t.Fatalf("ledger hash %s not found in %s", ledgerHash, data)
+	return ""
+}
+
+func TestDecodeValidation(t *testing.T) {
+	for _, want := range realValidations {
+		v, err := DecodeValidation(want.data)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if v.LedgerSequence != want.ledgerSequence || v.LedgerHash != want.ledgerHash {
+			t.Errorf("decoded ledger %d %s, want %d %s", v.LedgerSequence, v.LedgerHash, want.ledgerSequence, want.ledgerHash)
+		}
+		if v.Flags != ValidationFlagFullyCanonicalSig || v.Full() {
+			t.Errorf("decoded flags %08X", v.Flags)
+		}
+		if key, err := v.SigningKey(); err != nil || key != want.signingKey {
+			t.Errorf("signing key %s %v, want %s", key, err, want.signingKey)
+		}
+		if err := v.VerifySignature(); err != nil {
+			t.Errorf("%s: %v", want.signingKey, err)
+		}
+
+		tampered, err := DecodeValidation(tamper(t, want.data, want.ledgerHash))
+		if err != nil {
+			t.Fatal(err)
+		}
+		if err := tampered.VerifySignature(); !errors.Is(err, ErrInvalidSignature) {
+			t.Errorf("tampered validation: got %v, want %v", err, ErrInvalidSignature)
+		}
+	}
+
+	if _, err := DecodeValidation("228000000026006A124C"); err == nil {
+		t.Error("decoded an unsigned validation")
+	}
+	if err := (&Validation{}).VerifySignature(); err == nil {
+		t.Error("verified a validation that was not decoded")
+	}
+}
+
+func TestValidationFromStream(t *testing.T) {
+	want, other := realValidations[0], realValidations[1]
+	msg := models.ValidationStream{
+		Data:                want.data,
+		ValidationPublicKey: want.signingKey,
+		LedgerHash:          want.ledgerHash,
+	}
+	v, err := ValidationFromStream(msg)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := v.VerifySignature(); err != nil {
+		t.Error(err)
+	}
+
+	mismatched := msg
+	mismatched.ValidationPublicKey = other.signingKey
+	if _, err := ValidationFromStream(mismatched); err == nil {
+		t.Error("accepted a validation_public_key that did not sign the data")
+	}
+	mismatched = msg
+	mismatched.LedgerHash = other.ledgerHash
+	if _, err := ValidationFromStream(mismatched); err == nil {
+		t.Error("accepted a ledger_hash that is not in the data")
+	}
+	mismatched = msg
+	mismatched.Signature = "3045"
+	if _, err := ValidationFromStream(mismatched); err == nil {
+		t.Error("accepted a signature that is not in the data")
+	}
+}
+
+func TestValidationVerify(t *testing.T) {
+	// No mainnet validation is at hand together with the manifest of the
+	// validator that signed it, so this validator and its validation are
+	// made up; the real 2014 validations were signed with master keys.
+	tv := newTestValidator(t, "sEdSKaCy2JT7JaM7v95H9SxkhP9wS2r")
+	const ledgerHash = "E6DB7365949BF9814D76BCC730B01818EB9136A89DB224F3F9F5AAE4569D758E"
+	signingSeed := "sp5fghtJtpUorTwvof1NpDXAzNwf5"
+
+	registry := NewRegistry()
+	if err := registry.Apply(tv.manifest(t, 1, signingSeed)); err != nil {
+		t.Fatal(err)
+	}
+	_, signingKey := ephemeralKey(t, signingSeed)
+	v, err := ValidationFromStream(models.ValidationStream{
+		Data:                validation(t, signingSeed, 38129, ledgerHash),
+		ValidationPublicKey: signingKey,
+		LedgerHash:          ledgerHash,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !v.Full() {
+		t.Error("validation is not full")
+	}
+	masterKey, err := v.Verify(registry)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if masterKey != tv.masterKey(t) {
+		t.Errorf("got master key %s, want %s", masterKey, tv.masterKey(t))
+	}
+
+	tampered, err := DecodeValidation(tamper(t, validation(t, signingSeed, 38129, ledgerHash), ledgerHash))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := tampered.Verify(registry); !errors.Is(err, ErrInvalidSignature) {
+		t.Errorf("tampered validation: got %v, want %v", err, ErrInvalidSignature)
+	}
+
+	// A validly signed validation by a key no manifest delegates to.
+	unknown, err := DecodeValidation(realValidations[0].data)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := unknown.Verify(registry); !errors.Is(err, ErrUnknownSigningKey) {
+		t.Errorf("unknown signing key: got %v, want %v", err, ErrUnknownSigningKey)
+	}
+}
@@ -0,0 +1,74 @@
+package wallet
+
+import (
+	"encoding/hex"
+	"errors"
+	"strings"
+
+	"github.com/xrpscan/xrpl-go/addresscodec"
+	"github.com/xrpscan/xrpl-go/binarycodec"
+	"github.com/xrpscan/xrpl-go/keypairs"
+	"github.com/xrpscan/xrpl-go/models"
+)
+
+// AuthorizeChannel signs a claim against a payment channel, like the
+// channel_authorize method. channel is the hex channel ID and amount the
+// cumulative amount of XRP, in drops, that the claim redeems. Returns the
+// hex encoded signature.
+func AuthorizeChannel(channel string, amount string, w *Wallet) (string, error) {
+	return AuthorizeChannelWith(channel, amount, w.Signer())
+}
+
+// AuthorizeChannelWith signs a payment channel claim like AuthorizeChannel,
+// with the key of signer.
+func AuthorizeChannelWith(channel string, amount string, signer Signer) (string, error) {
+	data, err := binarycodec.EncodeForSigningClaim(channel, amount)
+	if err != nil {
+		return "", err
+	}
+	message, err := hex.DecodeString(data)
+	if err != nil {
+		return "", err
+	}
+	return signWith(message, signer)
+}
+
+// AuthorizeChannel signs a payment channel claim with the wallet's key.
+func (w *Wallet) AuthorizeChannel(channel string, amount string) (string, error) {
+	return AuthorizeChannel(channel, amount, w)
+}
+
+// VerifyChannelClaim reports whether signature authorizes a claim of amount
+// drops against channel, like the channel_verify method. publicKey is hex
+// or a base58 account public key.
+func VerifyChannelClaim(channel string, amount string, signature string, publicKey string) bool {
+	if strings.HasPrefix(publicKey, "a") {
+		key, err := addresscodec.DecodeAccountPublic(publicKey)
+		if err != nil {
+			return false
+		}
+		publicKey = hex.EncodeToString(key)
+	}
+	data, err := binarycodec.EncodeForSigningClaim(channel, amount)
+	if err != nil {
+		return false
+	}
+	message, err := hex.DecodeString(data)
+	if err != nil {
+		return false
+	}
+	return keypairs.Verify(message, signature, publicKey)
+}
+
+// VerifyChannelClaimTransaction checks the claim carried by a
+// PaymentChannelClaim transaction: Signature must authorize Balance with
+// PublicKey.
+func VerifyChannelClaimTransaction(tx models.TransactionPaymentChannelClaim) error {
+	if tx.Signature == "" || tx.PublicKey == "" || tx.Balance == "" {
+		return errors.New("transaction carries no claim")
+	}
+	if !VerifyChannelClaim(tx.Channel, tx.Balance, tx.Signature, tx.PublicKey) {
+		return errors.New("invalid payment channel claim signature")
+	}
+	return nil
+}
@@ -0,0 +1,93 @@
+package wallet
+
+import (
+	"testing"
+
+	"github.com/xrpscan/xrpl-go/models"
+)
+
+const testChannel = "5DB01B7FFED6B67E6B0414DED11E051D2EE2B7619CE0EAA6286D67A3A4D5BDB3"
+
+func TestAuthorizeChannel(t *testing.T) {
+	// From the xrpl.js authorizeChannel tests, as carried over to the
+	// github.com/Peersyst/xrpl-go v0.1.15 wallet tests. Both key types sign
+	// deterministically.
+	tests := []struct {
+		seed, amount, signature string
+	}{
+		{"snGHNrPbHrdUcszeuDEigMdC1Lyyd", "1000000", "304402204E7052F33DDAFAAA55C9F5B132A5E50EE95B2CF68C0902F61DFE77299BC893740220353640B951DCD24371C16868B3F91B78D38B6F3FD1E826413CDF891FA8250AAC"},
+		{"sEdSuqBPSQaood2DmNYVkwWTn1oQTj2", "1000000", "7E1C217A3E4B3C107B7A356E665088B4FBA6464C48C58267BEF64975E3375EA338AE22E6714E3F5E734AE33E6B97AAD59058E1E196C1F92346FC1498D0674404"},
+		{"snGHNrPbHrdUcszeuDEigMdC1Lyyd", "5000000", "304402202DF006FDE665C8A15628991A946629DDD08F7677E75C54619A96E9872BCC615F02206689262B5F102992346E5D84CA4EC73E947906073E4B2873DCDBEE54AFE948C3"},
+		{"sEdSuqBPSQaood2DmNYVkwWTn1oQTj2", "5000000", "AEEFCF001061F4E0368805B8A56D116EA8B9E4879A69C5B56A5B7E0F6ABD63E63341D56247192104012BC6AAEA71B1C97E466F47DA0736EFAD462481B165FB0E"},
+		{"snGHNrPbHrdUcszeuDEigMdC1Lyyd", "0", "3044022069888D92E1F4104FAD7BA66D8DA69278E579FE6EDAF32E87ACF481A6383C4AEB02204E0286429FF9842724627A08EAFD1A8356A6B36994DA6F386D2363C5D3AAFE7C"},
+	}
+	for _, test := range tests {
+		w := mustWallet(t, test.seed)
+		signature, err := w.AuthorizeChannel(testChannel, test.amount)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if signature != test.signature {
+			t.Errorf("%s %s: got %s, want %s", test.seed, test.amount, signature, test.signature)
+		}
+
+		if !VerifyChannelClaim(testChannel, test.amount, signature, w.PublicKey) {
+			t.Errorf("%s %s: signature does not verify", test.seed, test.amount)
+		}
+		if VerifyChannelClaim(testChannel, test.amount+"1", signature, w.PublicKey) {
+			t.Errorf("%s %s: verified for another amount", test.seed, test.amount)
+		}
+		otherChannel := "6" + testChannel[1:]
+		if VerifyChannelClaim(otherChannel, test.amount, signature, w.PublicKey) {
+			t.Errorf("%s %s: verified for another channel", test.seed, test.amount)
+		}
+	}
+
+	w := mustWallet(t, "snGHNrPbHrdUcszeuDEigMdC1Lyyd")
+	for _, args := range [][2]string{{"invalid-id", "1000000"}, {testChannel[2:], "1000000"}, {testChannel, "1.5"}, {testChannel, "-1"}} {
+		if _, err := w.AuthorizeChannel(args[0], args[1]); err == nil {
+			t.Errorf("authorized channel %q amount %q", args[0], args[1])
+		}
+	}
+}
+
+func TestVerifyChannelClaim(t *testing.T) {
+	// The channel_verify example on xrpl.org, with a base58 public key, as
+	// in the github.com/Peersyst/xrpl-go v0.1.15 channel verify tests.
+	const (
+		publicKey = "aB44YfzW24VDEJQ2UuLPV2PvqcPCSoLnL7y5M1EzhdW4LnK5xMS3"
+		signature = "304402204EF0AFB78AC23ED1C472E74F4299C0C21F1B21D07EFC0A3838A420F76D783A400220154FB11B6F54320666E4C36CA7F686C16A3A0456800BBC43746F34AF50290064"
+	)
+	if !VerifyChannelClaim(testChannel, "1000000", signature, publicKey) {
+		t.Error("channel_verify example does not verify")
+	}
+	if VerifyChannelClaim(testChannel, "999999", signature, publicKey) {
+		t.Error("verified for another amount")
+	}
+	if VerifyChannelClaim(testChannel, "1000000", signature, "aB44YfzW24VDEJQ2UuLPV2PvqcPCSoLnL7y5M1EzhdW4LnK5xMS4") {
+		t.Error("verified with an invalid public key")
+	}
+
+	w := mustWallet(t, "sEdSuqBPSQaood2DmNYVkwWTn1oQTj2")
+	claim, err := w.AuthorizeChannel(testChannel, "1000000")
+	if err != nil {
+		t.Fatal(err)
+	}
+	tx := models.TransactionPaymentChannelClaim{
+		Channel:   testChannel,
+		Balance:   "1000000",
+		Signature: claim,
+		PublicKey: w.PublicKey,
+	}
+	if err := VerifyChannelClaimTransaction(tx); err != nil {
+		t.Error(err)
+	}
+	tx.Balance = "2000000"
+	if err := VerifyChannelClaimTransaction(tx); err == nil {
+		t.Error("verified a claim for another balance")
+	}
+	tx.Signature = ""
+	if err := VerifyChannelClaimTransaction(tx); err == nil {
+		t.Error("verified a transaction without a claim")
+	}
+}
@@ -0,0 +1,139 @@
+package wallet
+
+import (
+	"crypto/hmac"
+	"crypto/sha512"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/decred/dcrd/dcrec/secp256k1/v4"
+	"github.com/tyler-smith/go-bip39"
+	"github.com/xrpscan/xrpl-go/addresscodec"
+	"github.com/xrpscan/xrpl-go/keypairs"
+)
+
+// hardened is added to BIP32 child indexes marked with an apostrophe.
+const hardened uint32 = 0x80000000
+
+// DerivationPath returns the BIP44 path of an XRP Ledger address,
+// m/44'/144'/account'/0/index, as used by Xumm and Ledger devices.
+func DerivationPath(account uint32, index uint32) string {
+	return fmt.Sprintf("m/44'/144'/%d'/0/%d", account, index)
+}
+
+// NewMnemonic returns a random BIP39 mnemonic of 12, 15, 18, 21 or 24
+// words for 128 to 256 bits of entropy.
+func NewMnemonic(bits int) (string, error) {
+	entropy, err := bip39.NewEntropy(bits)
+	if err != nil {
+		return "", err
+	}
+	return bip39.NewMnemonic(entropy)
+}
+
+// FromEntropy creates a Wallet from the 16 bytes of entropy of a seed.
+func FromEntropy(entropy []byte, algorithm addresscodec.Algorithm) (*Wallet, error) {
+	if len(entropy) != addresscodec.SeedLength {
+		return nil, fmt.Errorf("entropy must be %d bytes", addresscodec.SeedLength)
+	}
+	seed, err := keypairs.GenerateSeed(entropy, algorithm)
+	if err != nil {
+		return nil, err
+	}
+	return FromSeed(seed)
+}
+
+// FromMnemonic creates a secp256k1 Wallet from a BIP39 mnemonic and
+// optional passphrase, deriving the key at a BIP32 path such as
+// DerivationPath(0, 0). The Wallet has no Seed.
+func FromMnemonic(mnemonic string, passphrase string, path string) (*Wallet, error) {
+	seed, err := bip39.NewSeedWithErrorChecking(mnemonic, passphrase)
+	if err != nil {
+		return nil, err
+	}
+	indexes, err := parsePath(path)
+	if err != nil {
+		return nil, err
+	}
+	key, err := deriveBIP32(seed, indexes)
+	if err != nil {
+		return nil, err
+	}
+	privateKey := "00" + hexUpper(key.Serialize())
+	publicKey := hexUpper(key.PubKey().SerializeCompressed())
+	return FromKeypair(privateKey, publicKey)
+}
+
+// parsePath parses a BIP32 path of the form m/44'/144'/0'/0/0.
+func parsePath(path string) ([]uint32, error) {
+	parts := strings.Split(path, "/")
+	if parts[0] != "m" {
+		return nil, fmt.Errorf("invalid derivation path: %q", path)
+	}
+	indexes := make([]uint32, 0, len(parts)-1)
+	for _, part := range parts[1:] {
+		offset := uint32(0)
+		if strings.HasSuffix(part, "'") || strings.HasSuffix(part, "h") {
+			part = part[:len(part)-1]
+			offset = hardened
+		}
+		n, err := strconv.ParseUint(part, 10, 32)
+		if err != nil || uint32(n) >= hardened {
+			return nil, fmt.Errorf("invalid derivation path: %q", path)
+		}
+		indexes = append(indexes, uint32(n)+offset)
+	}
+	return indexes, nil
+}
+
+// deriveBIP32 derives the secp256k1 private key at indexes from a BIP39
+// seed, as specified by BIP32.
+func deriveBIP32(seed []byte, indexes []uint32) (*secp256k1.PrivateKey, error) {
+	key, chainCode, err := splitKey(hmacSHA512([]byte("Bitcoin seed"), seed), nil)
+	if err != nil {
+		return nil, err
+	}
+	for _, index := range indexes {
+		var data []byte
+		if index >= hardened {
+			private := key.Bytes()
+			data = append([]byte{0}, private[:]...)
+		} else {
+			var point secp256k1.JacobianPoint
+			secp256k1.ScalarBaseMultNonConst(&key, &point)
+			point.ToAffine()
+			data = secp256k1.NewPublicKey(&point.X, &point.Y).SerializeCompressed()
+		}
+		data = binary.BigEndian.AppendUint32(data, index)
+		key, chainCode, err = splitKey(hmacSHA512(chainCode, data), &key)
+		if err != nil {
+			return nil, err
+		}
+	}
+	return secp256k1.NewPrivateKey(&key), nil
+}
+
+// splitKey splits the output of HMAC-SHA512 into a private key, which is
+// added to parent if given, and a chain code.
+func splitKey(i []byte, parent *secp256k1.ModNScalar) (secp256k1.ModNScalar, []byte, error) {
+	var key secp256k1.ModNScalar
+	if overflow := key.SetByteSlice(i[:32]); overflow {
+		return key, nil, errors.New("derived key is invalid")
+	}
+	if parent != nil {
+		key.Add(parent)
+	}
+	if key.IsZero() {
+		return key, nil, errors.New("derived key is invalid")
+	}
+	return key, i[32:], nil
+}
+
+func hmacSHA512(key []byte, data []byte) []byte {
+	mac := hmac.New(sha512.New, key)
+	mac.Write(data)
+	return mac.Sum(nil)
+}
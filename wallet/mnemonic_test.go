@@ -0,0 +1,133 @@
+package wallet
+
+import (
+	"encoding/hex"
+	"strings"
+	"testing"
+
+	"github.com/xrpscan/xrpl-go/addresscodec"
+)
+
+func TestFromMnemonic(t *testing.T) {
+	// From the xrpl.js Wallet.fromMnemonic tests, as carried over to the
+	// github.com/Peersyst/xrpl-go v0.1.15 wallet tests. All are derived at
+	// m/44'/144'/0'/0/0.
+	tests := []struct {
+		mnemonic, publicKey, privateKey, address string
+	}{
+		{
+			mnemonic:   "midnight help already frost arena force omit physical please dwarf envelope royal dice surge eight often muscle tired blast begin waste fat rescue debate",
+			publicKey:  "028E831F16FD85ABEDA7577B6F4F26500FAB80AEA54B8A89EEC6FA44BCC7AF5678",
+			privateKey: "00C503FC86436D384F37F946E8DE3B8D9B4D09961424B7ABEF47DEE229A499D557",
+			address:    "rpa9S5fRbS2ZAf2cdFGtezhGYgom1iD4yh",
+		},
+		{
+			mnemonic:   "honey tip lunch empower omit invite nuclear tent brother sadness still exercise odor harbor alcohol huge wait swamp vessel tired swallow supreme silk spawn",
+			publicKey:  "038D4EA460687B0FF43E95D9CB56E439AC2C65D890C9FD8B553FF24997C5F91D9A",
+			privateKey: "00C2C6F996F5FAD5168CAAAD6E58E518641A41E8E164A6FADC540F52D728E2A4AB",
+			address:    "rPdkbYi6ok7HFpbRo6CSxeDUg951tVdA1m",
+		},
+		{
+			mnemonic:   "hen toe quarter robust elevator badge coconut all place desk pen school topic life seminar run salute paddle hurdle impact push amount oblige citizen",
+			publicKey:  "0388AE366DF0D8819760B82319C7A04CA06CC1D49EB5D41ED8DA0C8903DE8FF812",
+			privateKey: "001CBDDCA87FB57FB5A9D96ECE29D5710DA8421891F40CFA2262120DBB61E5050D",
+			address:    "rsKbuMTkzR5HU96j8pdGsSuzmZEiZ6mKh5",
+		},
+	}
+	for _, test := range tests {
+		w, err := FromMnemonic(test.mnemonic, "", DerivationPath(0, 0))
+		if err != nil {
+			t.Fatal(err)
+		}
+		if w.PublicKey != test.publicKey || w.PrivateKey != test.privateKey || w.ClassicAddress != test.address || w.Seed != "" {
+			t.Errorf("%s: got %+v", test.address, w)
+		}
+	}
+
+	valid := tests[0].mnemonic
+	invalid := strings.TrimSuffix(valid, "debate") + "abandon"
+	if _, err := FromMnemonic(invalid, "", DerivationPath(0, 0)); err == nil {
+		t.Error("accepted a mnemonic with an invalid checksum")
+	}
+	if w, err := FromMnemonic(valid, "passphrase", DerivationPath(0, 0)); err != nil || w.ClassicAddress == tests[0].address {
+		t.Errorf("passphrase ignored: %v", err)
+	}
+	for _, path := range []string{
+		"",
+		"44'/144'/0'/0/0",
+		"m/44'/144'/0'/0/",
+		"m/44'/144'/x'/0/0",
+		"m/44''/144'/0'/0/0",
+		"m/-1",
+		"m/2147483648",
+		"m/4294967296'",
+	} {
+		if _, err := FromMnemonic(valid, "", path); err == nil {
+			t.Errorf("accepted derivation path %q", path)
+		}
+	}
+}
+
+func TestDeriveBIP32(t *testing.T) {
+	// BIP32 test vector 1.
+	seed, _ := hex.DecodeString("000102030405060708090a0b0c0d0e0f")
+	tests := []struct {
+		path, publicKey, privateKey string
+	}{
+		{"m", "0339a36013301597daef41fbe593a02cc513d0b55527ec2df1050e2e8ff49c85c2", "e8f32e723decf4051aefac8e2c93c9c5b214313817cdb01a1494b917c8436b35"},
+		{"m/0'", "035a784662a4a20a65bf6aab9ae98a6c068a81c52e4b032c0fb5400c706cfccc56", "edb2e14f9ee77d26dd93b4ecede8d16ed408ce149b6cd80b0715a2d911a0afea"},
+		{"m/0'/1", "03501e454bf00751f24b1b489aa925215d66af2234e3891c3b21a52bedb3cd711c", ""},
+		{"m/0h/1/2h", "0357bfe1e341d01c69fe5654309956cbea516822fba8a601743a012a7896ee8dc2", ""},
+		{"m/0'/1/2'/2", "02e8445082a72f29b75ca48748a914df60622a609cacfce8ed0e35804560741d29", ""},
+		{"m/0'/1/2'/2/1000000000", "022a471424da5e657499d1ff51cb43c47481a03b1e77f951fe64cec9f5a48f7011", ""},
+	}
+	for _, test := range tests {
+		indexes, err := parsePath(test.path)
+		if err != nil {
+			t.Fatal(err)
+		}
+		key, err := deriveBIP32(seed, indexes)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if got := hex.EncodeToString(key.PubKey().SerializeCompressed()); got != test.publicKey {
+			t.Errorf("%s: public key %s, want %s", test.path, got, test.publicKey)
+		}
+		if got := hex.EncodeToString(key.Serialize()); test.privateKey != "" && got != test.privateKey {
+			t.Errorf("%s: private key %s, want %s", test.path, got, test.privateKey)
+		}
+	}
+}
+
+func TestFromEntropy(t *testing.T) {
+	// Zero entropy is the xrpl.js Wallet.fromEntropy vector; the seeds of
+	// "fakeRandomString" are from the github.com/Peersyst/xrpl-go v0.1.15
+	// keypairs tests.
+	tests := []struct {
+		entropy               []byte
+		algorithm             addresscodec.Algorithm
+		seed                  string
+		publicKey, privateKey string
+	}{
+		{make([]byte, 16), addresscodec.SECP256K1, "", "0390A196799EE412284A5D80BF78C3E84CBB80E1437A0AECD9ADF94D7FEAAFA284", "002512BBDFDBB77510883B7DCCBEF270B86DEAC8B64AC762873D75A1BEE6298665"},
+		{make([]byte, 16), addresscodec.ED25519, "", "ED1A7C082846CFF58FF9A892BA4BA2593151CCF1DBA59F37714CC9ED39824AF85F", "ED0B6CBAC838DFE7F47EA1BD0DF00EC282FDF45510C92161072CCFB84035390C4D"},
+		{[]byte("fakeRandomString"), addresscodec.SECP256K1, "sh3pdwcaoo7vt5rtrEZJ7a75LnDo3", "", ""},
+		{[]byte("fakeRandomString"), addresscodec.ED25519, "sEdTjrdnJaPE2NNjmavQqXQdrf71NiH", "", ""},
+	}
+	for _, test := range tests {
+		w, err := FromEntropy(test.entropy, test.algorithm)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if test.seed != "" && w.Seed != test.seed {
+			t.Errorf("%q: seed %s, want %s", test.entropy, w.Seed, test.seed)
+		}
+		if test.publicKey != "" && (w.PublicKey != test.publicKey || w.PrivateKey != test.privateKey) {
+			t.Errorf("%q: keys %s %s, want %s %s", test.entropy, w.PublicKey, w.PrivateKey, test.publicKey, test.privateKey)
+		}
+	}
+
+	if _, err := FromEntropy(make([]byte, 15), addresscodec.ED25519); err == nil {
+		t.Error("accepted 15 bytes of entropy")
+	}
+}
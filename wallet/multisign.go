@@ -0,0 +1,153 @@
+package wallet
+
+import (
+	"bytes"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"sort"
+
+	"github.com/xrpscan/xrpl-go/addresscodec"
+	"github.com/xrpscan/xrpl-go/binarycodec"
+)
+
+// SignFor adds the wallet's signature to tx as one signer of a
+// multi-signed transaction. SigningPubKey is set to the empty string, as
+// multi-signed transactions require. Signatures already in tx.Signers are
+// kept. tx is not modified. Returns the tx_blob and hash including this
+// signature; blobs from several signers are merged with Combine.
+func SignFor(tx map[string]interface{}, w *Wallet) (txBlob string, hash string, err error) {
+	return SignForWith(tx, w.ClassicAddress, w.Signer())
+}
+
+// SignForWith adds a signature by signer to tx like SignFor, on behalf of
+// account. account is the signer's address, which need not be derived
+// from the signer's key if that is the account's regular key.
+func SignForWith(tx map[string]interface{}, account string, signer Signer) (txBlob string, hash string, err error) {
+	if _, ok := tx["TxnSignature"]; ok {
+		return "", "", errors.New("transaction is already single-signed")
+	}
+	if key, ok := tx["SigningPubKey"]; ok && key != "" {
+		return "", "", errors.New("multi-signed transactions must have an empty SigningPubKey")
+	}
+
+	signed := make(map[string]interface{}, len(tx)+2)
+	for k, v := range tx {
+		signed[k] = v
+	}
+	signed["SigningPubKey"] = ""
+
+	data, err := binarycodec.EncodeForMultisigning(signed, account)
+	if err != nil {
+		return "", "", err
+	}
+	message, err := hex.DecodeString(data)
+	if err != nil {
+		return "", "", err
+	}
+	signature, err := signWith(message, signer)
+	if err != nil {
+		return "", "", err
+	}
+
+	var signers []interface{}
+	if existing, ok := tx["Signers"]; ok {
+		if signers, ok = existing.([]interface{}); !ok {
+			return "", "", errors.New("Signers must be an array")
+		}
+	}
+	signers = append(append([]interface{}{}, signers...), map[string]interface{}{
+		"Signer": map[string]interface{}{
+			"Account":       account,
+			"SigningPubKey": signer.PublicKey(),
+			"TxnSignature":  signature,
+		},
+	})
+	if signed["Signers"], err = sortSigners(signers); err != nil {
+		return "", "", err
+	}
+
+	txBlob, err = binarycodec.Encode(signed)
+	if err != nil {
+		return "", "", err
+	}
+	hash, err = binarycodec.HashTransaction(txBlob)
+	return txBlob, hash, err
+}
+
+// Combine merges the signatures of several multi-signed blobs of the same
+// transaction, as produced by SignFor, into one tx_blob ready for
+// submit_multisigned or submit. Returns an error if the blobs are not for
+// the same transaction or if an account signed more than once.
+func Combine(txBlobs ...string) (txBlob string, hash string, err error) {
+	if len(txBlobs) == 0 {
+		return "", "", errors.New("no transactions to combine")
+	}
+
+	var combined map[string]interface{}
+	var unsigned string
+	var signers []interface{}
+	for i, blob := range txBlobs {
+		tx, err := binarycodec.Decode(blob)
+		if err != nil {
+			return "", "", fmt.Errorf("transaction %d: %w", i, err)
+		}
+		txSigners, ok := tx["Signers"].([]interface{})
+		if !ok || len(txSigners) == 0 {
+			return "", "", fmt.Errorf("transaction %d is not multi-signed", i)
+		}
+		delete(tx, "Signers")
+		encoded, err := binarycodec.Encode(tx)
+		if err != nil {
+			return "", "", fmt.Errorf("transaction %d: %w", i, err)
+		}
+		if i == 0 {
+			combined, unsigned = tx, encoded
+		} else if encoded != unsigned {
+			return "", "", fmt.Errorf("transaction %d differs from transaction 0", i)
+		}
+		signers = append(signers, txSigners...)
+	}
+
+	if combined["Signers"], err = sortSigners(signers); err != nil {
+		return "", "", err
+	}
+	txBlob, err = binarycodec.Encode(combined)
+	if err != nil {
+		return "", "", err
+	}
+	hash, err = binarycodec.HashTransaction(txBlob)
+	return txBlob, hash, err
+}
+
+// sortSigners orders signer entries by the numeric value of their
+// AccountID, as rippled requires, and rejects duplicate accounts.
+func sortSigners(signers []interface{}) ([]interface{}, error) {
+	type entry struct {
+		accountID []byte
+		signer    interface{}
+	}
+	entries := make([]entry, 0, len(signers))
+	for _, s := range signers {
+		wrapper, _ := s.(map[string]interface{})
+		signer, _ := wrapper["Signer"].(map[string]interface{})
+		account, _ := signer["Account"].(string)
+		accountID, err := addresscodec.DecodeAccountID(account)
+		if err != nil {
+			return nil, fmt.Errorf("invalid signer entry %v: %w", s, err)
+		}
+		entries = append(entries, entry{accountID, s})
+	}
+	sort.Slice(entries, func(i, j int) bool {
+		return bytes.Compare(entries[i].accountID, entries[j].accountID) < 0
+	})
+
+	sorted := make([]interface{}, len(entries))
+	for i, e := range entries {
+		if i > 0 && bytes.Equal(e.accountID, entries[i-1].accountID) {
+			return nil, errors.New("account signed more than once")
+		}
+		sorted[i] = e.signer
+	}
+	return sorted, nil
+}
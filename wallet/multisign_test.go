@@ -0,0 +1,110 @@
+package wallet
+
+import (
+	"bytes"
+	"encoding/hex"
+	"testing"
+
+	"github.com/xrpscan/xrpl-go/addresscodec"
+	"github.com/xrpscan/xrpl-go/binarycodec"
+	"github.com/xrpscan/xrpl-go/keypairs"
+)
+
+func TestMultisign(t *testing.T) {
+	signers := []*Wallet{mustWallet(t, secpSeed), mustWallet(t, ed25519Seed), mustWallet(t, genesisSeed)}
+	tx := testPayment("rPT1Sjq2YGrBMTttX4GZHjKu9dyfzbpAYe")
+	tx["SigningPubKey"] = ""
+
+	blobs := make([]string, len(signers))
+	for i, w := range signers {
+		blob, _, err := SignFor(tx, w)
+		if err != nil {
+			t.Fatal(err)
+		}
+		blobs[i] = blob
+	}
+	// Combine in two different orders; the result must be the same.
+	txBlob, hash, err := Combine(blobs[2], blobs[0], blobs[1])
+	if err != nil {
+		t.Fatal(err)
+	}
+	again, _, err := Combine(blobs[1], blobs[2], blobs[0])
+	if err != nil || again != txBlob {
+		t.Errorf("Combine depends on order (%v)", err)
+	}
+	if hash != transactionID(t, txBlob) {
+		t.Errorf("hash %s, want %s", hash, transactionID(t, txBlob))
+	}
+
+	combined, err := binarycodec.Decode(txBlob)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if combined["SigningPubKey"] != "" {
+		t.Errorf("SigningPubKey %v, want empty", combined["SigningPubKey"])
+	}
+	entries := combined["Signers"].([]interface{})
+	if len(entries) != len(signers) {
+		t.Fatalf("%d signers, want %d", len(entries), len(signers))
+	}
+
+	unsigned, err := binarycodec.Encode(tx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var previous []byte
+	for _, e := range entries {
+		signer := e.(map[string]interface{})["Signer"].(map[string]interface{})
+		account := signer["Account"].(string)
+		accountID, err := addresscodec.DecodeAccountID(account)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if previous != nil && bytes.Compare(previous, accountID) >= 0 {
+			t.Errorf("signers are not sorted by AccountID")
+		}
+		previous = accountID
+
+		// SMT prefix, the fields without Signers and the signer's
+		// AccountID.
+		message, _ := hex.DecodeString("534D5400" + unsigned + hex.EncodeToString(accountID))
+		if !keypairs.Verify(message, signer["TxnSignature"].(string), signer["SigningPubKey"].(string)) {
+			t.Errorf("signature by %s does not verify", account)
+		}
+	}
+}
+
+func TestCombineRejects(t *testing.T) {
+	a, b := mustWallet(t, secpSeed), mustWallet(t, ed25519Seed)
+	tx := testPayment("rPT1Sjq2YGrBMTttX4GZHjKu9dyfzbpAYe")
+	blobA, _, err := SignFor(tx, a)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	other := testPayment("rPT1Sjq2YGrBMTttX4GZHjKu9dyfzbpAYe")
+	other["Sequence"] = 8
+	blobB, _, err := SignFor(other, b)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, _, err := Combine(blobA, blobB); err == nil {
+		t.Error("combined different transactions")
+	}
+	if _, _, err := Combine(blobA, blobA); err == nil {
+		t.Error("combined two signatures by the same account")
+	}
+
+	single, _, err := Sign(testPayment(a.ClassicAddress), a)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, _, err := Combine(single); err == nil {
+		t.Error("combined a single-signed transaction")
+	}
+
+	tx["SigningPubKey"] = a.PublicKey
+	if _, _, err := SignFor(tx, b); err == nil {
+		t.Error("multi-signed a transaction with a SigningPubKey")
+	}
+}
@@ -0,0 +1,56 @@
+package wallet
+
+import (
+	"encoding/hex"
+	"errors"
+
+	"github.com/xrpscan/xrpl-go/binarycodec"
+)
+
+// Sign single-signs tx, a transaction in JSON form, with the wallet's key.
+// tx is not modified. Returns the signed tx_blob, ready for the submit
+// method, and the transaction's hash.
+func Sign(tx map[string]interface{}, w *Wallet) (txBlob string, hash string, err error) {
+	return SignWith(tx, w.Signer())
+}
+
+// SignWith single-signs tx like Sign, with the key of signer.
+func SignWith(tx map[string]interface{}, signer Signer) (txBlob string, hash string, err error) {
+	if _, ok := tx["TxnSignature"]; ok {
+		return "", "", errors.New("transaction is already signed")
+	}
+	if _, ok := tx["Signers"]; ok {
+		return "", "", errors.New("transaction is multi-signed")
+	}
+
+	signed := make(map[string]interface{}, len(tx)+2)
+	for k, v := range tx {
+		signed[k] = v
+	}
+	signed["SigningPubKey"] = signer.PublicKey()
+
+	data, err := binarycodec.EncodeForSigning(signed)
+	if err != nil {
+		return "", "", err
+	}
+	message, err := hex.DecodeString(data)
+	if err != nil {
+		return "", "", err
+	}
+	signed["TxnSignature"], err = signWith(message, signer)
+	if err != nil {
+		return "", "", err
+	}
+
+	txBlob, err = binarycodec.Encode(signed)
+	if err != nil {
+		return "", "", err
+	}
+	hash, err = binarycodec.HashTransaction(txBlob)
+	return txBlob, hash, err
+}
+
+// Sign single-signs tx with the wallet's key. See Sign.
+func (w *Wallet) Sign(tx map[string]interface{}) (txBlob string, hash string, err error) {
+	return Sign(tx, w)
+}
@@ -0,0 +1,122 @@
+package wallet
+
+import (
+	"crypto/sha512"
+	"encoding/hex"
+	"strings"
+	"testing"
+
+	"github.com/xrpscan/xrpl-go/binarycodec"
+	"github.com/xrpscan/xrpl-go/keypairs"
+)
+
+const (
+	secpSeed    = "sp5fghtJtpUorTwvof1NpDXAzNwf5"
+	ed25519Seed = "sEdSKaCy2JT7JaM7v95H9SxkhP9wS2r"
+	genesisSeed = "snoPBrXtMeMyMHUVTgbuqAfg1SUTb"
+)
+
+func mustWallet(t *testing.T, seed string) *Wallet {
+	t.Helper()
+	w, err := FromSeed(seed)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return w
+}
+
+func testPayment(account string) map[string]interface{} {
+	return map[string]interface{}{
+		"TransactionType":    "Payment",
+		"Account":            account,
+		"Destination":        "rHb9CJAWyB4rj91VRWn96DkukG4bwdtyTh",
+		"Amount":             "1000000",
+		"Fee":                "12",
+		"Flags":              0,
+		"Sequence":           7,
+		"LastLedgerSequence": 90000000,
+	}
+}
+
+// transactionID hashes a blob with the TXN prefix, independently of
+// binarycodec.HashTransaction.
+func transactionID(t *testing.T, txBlob string) string {
+	t.Helper()
+	blob, err := hex.DecodeString(txBlob)
+	if err != nil {
+		t.Fatal(err)
+	}
+	h := sha512.Sum512(append([]byte("TXN\x00"), blob...))
+	return strings.ToUpper(hex.EncodeToString(h[:32]))
+}
+
+func TestSign(t *testing.T) {
+	for _, seed := range []string{secpSeed, ed25519Seed} {
+		w := mustWallet(t, seed)
+		tx := testPayment(w.ClassicAddress)
+		txBlob, hash, err := Sign(tx, w)
+		if err != nil {
+			t.Fatalf("%s: %v", seed, err)
+		}
+		if _, ok := tx["TxnSignature"]; ok {
+			t.Errorf("%s: Sign modified its input", seed)
+		}
+		if hash != transactionID(t, txBlob) {
+			t.Errorf("%s: hash %s, want %s", seed, hash, transactionID(t, txBlob))
+		}
+
+		signed, err := binarycodec.Decode(txBlob)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if signed["SigningPubKey"] != w.PublicKey {
+			t.Errorf("%s: SigningPubKey %v, want %s", seed, signed["SigningPubKey"], w.PublicKey)
+		}
+
+		// The signature covers the STX prefix and every field except
+		// TxnSignature.
+		signature := signed["TxnSignature"].(string)
+		tx["SigningPubKey"] = w.PublicKey
+		unsigned, err := binarycodec.Encode(tx)
+		if err != nil {
+			t.Fatal(err)
+		}
+		message, _ := hex.DecodeString("53545800" + unsigned)
+		if !keypairs.Verify(message, signature, w.PublicKey) {
+			t.Errorf("%s: TxnSignature does not verify", seed)
+		}
+	}
+}
+
+func TestSignDeterministic(t *testing.T) {
+	// Both RFC 6979 secp256k1 and ed25519 signatures are deterministic, so
+	// signing twice gives the same blob.
+	for _, seed := range []string{secpSeed, ed25519Seed} {
+		w := mustWallet(t, seed)
+		first, _, err := Sign(testPayment(w.ClassicAddress), w)
+		if err != nil {
+			t.Fatal(err)
+		}
+		second, _, err := Sign(testPayment(w.ClassicAddress), w)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if first != second {
+			t.Errorf("%s: signatures differ", seed)
+		}
+	}
+}
+
+func TestSignRejectsSigned(t *testing.T) {
+	w := mustWallet(t, secpSeed)
+	tx := testPayment(w.ClassicAddress)
+	tx["TxnSignature"] = "3044"
+	if _, _, err := Sign(tx, w); err == nil {
+		t.Error("signed a transaction that already has a signature")
+	}
+	tx = testPayment(w.ClassicAddress)
+	tx["Signers"] = []interface{}{}
+	if _, _, err := Sign(tx, w); err == nil {
+		t.Error("single-signed a multi-signed transaction")
+	}
+}
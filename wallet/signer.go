@@ -0,0 +1,80 @@
+package wallet
+
+import (
+	"encoding/hex"
+	"errors"
+	"strings"
+
+	"github.com/xrpscan/xrpl-go/keypairs"
+)
+
+// Signer signs with one key pair on behalf of the functions in this
+// package, so that private keys can stay in an HSM, a key management
+// service or a separate signing process. Wallets sign in process through
+// Wallet.Signer.
+type Signer interface {
+	// PublicKey returns the hex encoded public key: a 33 byte compressed
+	// secp256k1 point, or an ed25519 key prefixed with "ED".
+	PublicKey() string
+
+	// Sign signs digest. For secp256k1 keys digest is the 32 byte
+	// SHA-512Half of the signing data, and the signature may be DER
+	// encoded or the 64 byte concatenation of R and S; it is normalized
+	// to canonical DER with a low S value. Ed25519 does not sign digests,
+	// so ed25519 keys are passed the signing data itself.
+	Sign(digest []byte) ([]byte, error)
+}
+
+// localSigner signs with a private key held in memory.
+type localSigner struct {
+	privateKey string
+	publicKey  string
+}
+
+// Signer returns a Signer that uses the wallet's private key.
+func (w *Wallet) Signer() Signer {
+	return &localSigner{privateKey: w.PrivateKey, publicKey: w.PublicKey}
+}
+
+func (s *localSigner) PublicKey() string {
+	return s.publicKey
+}
+
+func (s *localSigner) Sign(digest []byte) ([]byte, error) {
+	if isED25519(s.publicKey) {
+		sig, err := keypairs.Sign(digest, s.privateKey)
+		if err != nil {
+			return nil, err
+		}
+		return hex.DecodeString(sig)
+	}
+	return keypairs.SignDigest(digest, s.privateKey)
+}
+
+// signWith signs message with s and returns the hex encoded signature,
+// checked against the signer's public key.
+func signWith(message []byte, s Signer) (string, error) {
+	publicKey := s.PublicKey()
+	var sig []byte
+	var err error
+	if isED25519(publicKey) {
+		sig, err = s.Sign(message)
+	} else {
+		sig, err = s.Sign(keypairs.Sha512Half(message))
+		if err == nil {
+			sig, err = keypairs.NormalizeSignature(sig)
+		}
+	}
+	if err != nil {
+		return "", err
+	}
+	signature := hexUpper(sig)
+	if !keypairs.Verify(message, signature, publicKey) {
+		return "", errors.New("signer returned an invalid signature")
+	}
+	return signature, nil
+}
+
+func isED25519(publicKey string) bool {
+	return strings.HasPrefix(strings.ToUpper(publicKey), "ED")
+}
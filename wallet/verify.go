@@ -0,0 +1,289 @@
+package wallet
+
+import (
+	"bytes"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math"
+	"strconv"
+
+	"github.com/xrpscan/xrpl-go/addresscodec"
+	"github.com/xrpscan/xrpl-go/binarycodec"
+	"github.com/xrpscan/xrpl-go/keypairs"
+)
+
+// lsfDisableMaster is the AccountRoot flag set when the master key may not
+// sign for the account.
+const lsfDisableMaster = 0x00100000
+
+// Authorization lists the keys that may sign for an account, as recorded in
+// its AccountRoot and SignerList ledger entries.
+type Authorization struct {
+	MasterKeyDisabled bool
+	RegularKey        string            // Classic address, empty if none
+	SignerQuorum      uint32            // Zero if the account has no signer list
+	SignerEntries     map[string]uint16 // Signer account to weight
+
+	// SignerRegularKeys maps signer accounts to their regular keys, for
+	// signers that sign with a regular key rather than their master key.
+	SignerRegularKeys map[string]string
+	// SignerMasterKeyDisabled holds the signer accounts whose master key is
+	// disabled.
+	SignerMasterKeyDisabled map[string]bool
+}
+
+// AuthorizationFromLedger builds an Authorization from an AccountRoot
+// ledger entry and, if the account has one, its SignerList entry, both in
+// JSON form as returned by account_info with signer_lists set or by
+// ledger_entry. signerList may be nil. The AccountRoot entries of signers
+// may follow, to allow their regular keys and to reject their master keys
+// when disabled; signers without one may only sign with their master key.
+func AuthorizationFromLedger(accountRoot map[string]interface{}, signerList map[string]interface{}, signerAccounts ...map[string]interface{}) (Authorization, error) {
+	var auth Authorization
+	var err error
+	auth.MasterKeyDisabled, err = masterKeyDisabled(accountRoot)
+	if err != nil {
+		return auth, err
+	}
+	auth.RegularKey, _ = accountRoot["RegularKey"].(string)
+
+	if signerList == nil {
+		return auth, nil
+	}
+	quorum, ok := toUint32(signerList["SignerQuorum"])
+	if !ok {
+		return auth, errors.New("SignerList has no SignerQuorum")
+	}
+	auth.SignerQuorum = quorum
+	entries, _ := signerList["SignerEntries"].([]interface{})
+	auth.SignerEntries = make(map[string]uint16, len(entries))
+	for _, e := range entries {
+		wrapper, _ := e.(map[string]interface{})
+		entry, _ := wrapper["SignerEntry"].(map[string]interface{})
+		account, _ := entry["Account"].(string)
+		weight, ok := toUint32(entry["SignerWeight"])
+		if account == "" || !ok {
+			return auth, fmt.Errorf("invalid SignerEntry: %v", e)
+		}
+		auth.SignerEntries[account] = uint16(weight)
+	}
+
+	auth.SignerRegularKeys = make(map[string]string)
+	auth.SignerMasterKeyDisabled = make(map[string]bool)
+	for _, root := range signerAccounts {
+		account, _ := root["Account"].(string)
+		if _, ok := auth.SignerEntries[account]; !ok {
+			return auth, fmt.Errorf("%s is not in the signer list", account)
+		}
+		disabled, err := masterKeyDisabled(root)
+		if err != nil {
+			return auth, err
+		}
+		auth.SignerMasterKeyDisabled[account] = disabled
+		if regularKey, _ := root["RegularKey"].(string); regularKey != "" {
+			auth.SignerRegularKeys[account] = regularKey
+		}
+	}
+	return auth, nil
+}
+
+func masterKeyDisabled(accountRoot map[string]interface{}) (bool, error) {
+	flags, ok := accountRoot["Flags"]
+	if !ok {
+		return false, nil
+	}
+	n, ok := toUint32(flags)
+	if !ok {
+		return false, errors.New("invalid AccountRoot Flags")
+	}
+	return n&lsfDisableMaster != 0, nil
+}
+
+// VerifySignature checks the signatures of a transaction in JSON form, as
+// received from the tx method or the transactions stream: TxnSignature
+// against SigningPubKey for single-signed transactions, or every entry in
+// Signers for multi-signed ones. If tx has a hash, it must match. It does
+// not check that the keys may sign for the account; see VerifyTransaction.
+func VerifySignature(tx map[string]interface{}) error {
+	tx = withAmount(tx)
+	if hash, ok := tx["hash"].(string); ok {
+		computed, err := binarycodec.HashTransactionJSON(tx)
+		if err != nil {
+			return err
+		}
+		if computed != hash {
+			return fmt.Errorf("transaction hash mismatch: computed %s", computed)
+		}
+	}
+
+	if signers, ok := tx["Signers"].([]interface{}); ok {
+		if key, _ := tx["SigningPubKey"].(string); key != "" {
+			return errors.New("multi-signed transaction has a SigningPubKey")
+		}
+		if len(signers) == 0 {
+			return errors.New("transaction has no signers")
+		}
+		var previous []byte
+		for _, s := range signers {
+			signer, err := signerEntry(s)
+			if err != nil {
+				return err
+			}
+			accountID, err := addresscodec.DecodeAccountID(signer.account)
+			if err != nil {
+				return err
+			}
+			if previous != nil && bytes.Compare(previous, accountID) >= 0 {
+				return errors.New("signers are not sorted by account")
+			}
+			previous = accountID
+
+			data, err := binarycodec.EncodeForMultisigning(tx, signer.account)
+			if err != nil {
+				return err
+			}
+			if !verifyHex(data, signer.signature, signer.publicKey) {
+				return fmt.Errorf("invalid signature by signer %s", signer.account)
+			}
+		}
+		return nil
+	}
+
+	publicKey, _ := tx["SigningPubKey"].(string)
+	signature, _ := tx["TxnSignature"].(string)
+	if publicKey == "" || signature == "" {
+		return errors.New("transaction is not signed")
+	}
+	data, err := binarycodec.EncodeForSigning(tx)
+	if err != nil {
+		return err
+	}
+	if !verifyHex(data, signature, publicKey) {
+		return errors.New("invalid transaction signature")
+	}
+	return nil
+}
+
+// VerifyTransaction checks the signatures of tx like VerifySignature, and
+// that they were made with keys auth allows to sign for the account: the
+// master key unless disabled, the regular key, or signers from the signer
+// list whose weights meet its quorum.
+func VerifyTransaction(tx map[string]interface{}, auth Authorization) error {
+	if err := VerifySignature(tx); err != nil {
+		return err
+	}
+	account, _ := tx["Account"].(string)
+
+	if signers, ok := tx["Signers"].([]interface{}); ok {
+		if auth.SignerQuorum == 0 {
+			return fmt.Errorf("account %s has no signer list", account)
+		}
+		var weight uint32
+		for _, s := range signers {
+			signer, err := signerEntry(s)
+			if err != nil {
+				return err
+			}
+			signerWeight, ok := auth.SignerEntries[signer.account]
+			if !ok {
+				return fmt.Errorf("%s is not in the signer list of %s", signer.account, account)
+			}
+			address, err := keypairs.DeriveAddress(signer.publicKey)
+			if err != nil {
+				return err
+			}
+			switch {
+			case address == signer.account && auth.SignerMasterKeyDisabled[signer.account]:
+				return fmt.Errorf("master key of signer %s is disabled", signer.account)
+			case address != signer.account && address != auth.SignerRegularKeys[signer.account]:
+				return fmt.Errorf("signer %s signed with a key it is not known to control", signer.account)
+			}
+			weight += uint32(signerWeight)
+		}
+		if weight < auth.SignerQuorum {
+			return fmt.Errorf("signer weight %d is below quorum %d", weight, auth.SignerQuorum)
+		}
+		return nil
+	}
+
+	publicKey, _ := tx["SigningPubKey"].(string)
+	address, err := keypairs.DeriveAddress(publicKey)
+	if err != nil {
+		return err
+	}
+	switch {
+	case address == account && auth.MasterKeyDisabled:
+		return fmt.Errorf("master key of %s is disabled", account)
+	case address == account:
+		return nil
+	case auth.RegularKey != "" && address == auth.RegularKey:
+		return nil
+	default:
+		return fmt.Errorf("key %s may not sign for %s", publicKey, account)
+	}
+}
+
+// withAmount returns tx with Amount set from DeliverMax, which replaces it
+// in API v2 responses but is not part of the signed transaction.
+func withAmount(tx map[string]interface{}) map[string]interface{} {
+	deliverMax, ok := tx["DeliverMax"]
+	if !ok {
+		return tx
+	}
+	if _, ok := tx["Amount"]; ok {
+		return tx
+	}
+	copied := make(map[string]interface{}, len(tx))
+	for k, v := range tx {
+		copied[k] = v
+	}
+	copied["Amount"] = deliverMax
+	delete(copied, "DeliverMax")
+	return copied
+}
+
+type signer struct {
+	account   string
+	publicKey string
+	signature string
+}
+
+func signerEntry(entry interface{}) (signer, error) {
+	wrapper, _ := entry.(map[string]interface{})
+	fields, _ := wrapper["Signer"].(map[string]interface{})
+	var s signer
+	s.account, _ = fields["Account"].(string)
+	s.publicKey, _ = fields["SigningPubKey"].(string)
+	s.signature, _ = fields["TxnSignature"].(string)
+	if s.account == "" || s.publicKey == "" || s.signature == "" {
+		return s, fmt.Errorf("invalid Signer entry: %v", entry)
+	}
+	return s, nil
+}
+
+func verifyHex(data string, signature string, publicKey string) bool {
+	message, err := hex.DecodeString(data)
+	if err != nil {
+		return false
+	}
+	return keypairs.Verify(message, signature, publicKey)
+}
+
+// toUint32 accepts numbers decoded by encoding/json or binarycodec.
+func toUint32(v interface{}) (uint32, bool) {
+	switch n := v.(type) {
+	case float64:
+		return uint32(n), n >= 0 && n <= math.MaxUint32
+	case uint32:
+		return n, true
+	case int32:
+		return uint32(n), n >= 0
+	case json.Number:
+		i, err := strconv.ParseUint(n.String(), 10, 32)
+		return uint32(i), err == nil
+	default:
+		return 0, false
+	}
+}
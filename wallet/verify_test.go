@@ -0,0 +1,95 @@
+package wallet
+
+import (
+	"testing"
+
+	"github.com/xrpscan/xrpl-go/binarycodec"
+)
+
+func TestVerifyDeliverMax(t *testing.T) {
+	w := mustWallet(t, secpSeed)
+	txBlob, hash, err := Sign(testPayment(w.ClassicAddress), w)
+	if err != nil {
+		t.Fatal(err)
+	}
+	// An API v2 response sends DeliverMax instead of Amount.
+	tx, err := binarycodec.Decode(txBlob)
+	if err != nil {
+		t.Fatal(err)
+	}
+	tx["DeliverMax"] = tx["Amount"]
+	delete(tx, "Amount")
+	tx["hash"] = hash
+	if err := VerifySignature(tx); err != nil {
+		t.Error(err)
+	}
+	if _, ok := tx["Amount"]; ok {
+		t.Error("VerifySignature modified its input")
+	}
+}
+
+func TestVerifySignerKeys(t *testing.T) {
+	master, regular := mustWallet(t, secpSeed), mustWallet(t, ed25519Seed)
+	regularKey := mustWallet(t, genesisSeed)
+	account := "rPT1Sjq2YGrBMTttX4GZHjKu9dyfzbpAYe"
+	tx := testPayment(account)
+	tx["SigningPubKey"] = ""
+
+	blobA, _, err := SignFor(tx, master)
+	if err != nil {
+		t.Fatal(err)
+	}
+	// The second signer signs with its regular key.
+	blobB, _, err := SignForWith(tx, regular.ClassicAddress, regularKey.Signer())
+	if err != nil {
+		t.Fatal(err)
+	}
+	txBlob, _, err := Combine(blobA, blobB)
+	if err != nil {
+		t.Fatal(err)
+	}
+	signed, err := binarycodec.Decode(txBlob)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	accountRoot := map[string]interface{}{"Account": account, "Flags": float64(0)}
+	signerList := map[string]interface{}{
+		"SignerQuorum": float64(2),
+		"SignerEntries": []interface{}{
+			map[string]interface{}{"SignerEntry": map[string]interface{}{"Account": master.ClassicAddress, "SignerWeight": float64(1)}},
+			map[string]interface{}{"SignerEntry": map[string]interface{}{"Account": regular.ClassicAddress, "SignerWeight": float64(1)}},
+		},
+	}
+	withRegularKey := map[string]interface{}{"Account": regular.ClassicAddress, "Flags": float64(0), "RegularKey": regularKey.ClassicAddress}
+
+	auth, err := AuthorizationFromLedger(accountRoot, signerList)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if VerifyTransaction(signed, auth) == nil {
+		t.Error("accepted a regular key that is not in the ledger")
+	}
+
+	auth, err = AuthorizationFromLedger(accountRoot, signerList, withRegularKey)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := VerifyTransaction(signed, auth); err != nil {
+		t.Error(err)
+	}
+
+	disabled := map[string]interface{}{"Account": master.ClassicAddress, "Flags": float64(lsfDisableMaster)}
+	auth, err = AuthorizationFromLedger(accountRoot, signerList, withRegularKey, disabled)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if VerifyTransaction(signed, auth) == nil {
+		t.Error("accepted a signer's disabled master key")
+	}
+
+	stranger := map[string]interface{}{"Account": account}
+	if _, err := AuthorizationFromLedger(accountRoot, signerList, stranger); err == nil {
+		t.Error("accepted the AccountRoot of an account that is not a signer")
+	}
+}
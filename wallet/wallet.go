@@ -0,0 +1,69 @@
+// Package wallet holds XRP Ledger key pairs and signs transactions with
+// them locally, without sending secrets to a server.
+package wallet
+
+import (
+	"encoding/hex"
+	"errors"
+	"strings"
+
+	"github.com/xrpscan/xrpl-go/addresscodec"
+	"github.com/xrpscan/xrpl-go/keypairs"
+)
+
+// Wallet is a key pair and the classic address derived from it. Seed is
+// empty for wallets created from a key pair or a mnemonic.
+type Wallet struct {
+	PublicKey      string
+	PrivateKey     string
+	ClassicAddress string
+	Seed           string
+}
+
+// FromSeed creates a Wallet from a secp256k1 or ed25519 seed.
+func FromSeed(seed string) (*Wallet, error) {
+	privateKey, publicKey, err := keypairs.DeriveKeypair(seed)
+	if err != nil {
+		return nil, err
+	}
+	w, err := FromKeypair(privateKey, publicKey)
+	if err != nil {
+		return nil, err
+	}
+	w.Seed = seed
+	return w, nil
+}
+
+// FromKeypair creates a Wallet from hex encoded private and public keys.
+// The public key must be the one derived from the private key.
+func FromKeypair(privateKey string, publicKey string) (*Wallet, error) {
+	derived, err := keypairs.DerivePublicKey(privateKey)
+	if err != nil {
+		return nil, err
+	}
+	if !strings.EqualFold(derived, publicKey) {
+		return nil, errors.New("private key does not match public key")
+	}
+	address, err := keypairs.DeriveAddress(publicKey)
+	if err != nil {
+		return nil, err
+	}
+	return &Wallet{
+		PublicKey:      publicKey,
+		PrivateKey:     privateKey,
+		ClassicAddress: address,
+	}, nil
+}
+
+// Generate creates a Wallet from a new random seed.
+func Generate(algorithm addresscodec.Algorithm) (*Wallet, error) {
+	seed, err := keypairs.GenerateSeed(nil, algorithm)
+	if err != nil {
+		return nil, err
+	}
+	return FromSeed(seed)
+}
+
+func hexUpper(b []byte) string {
+	return strings.ToUpper(hex.EncodeToString(b))
+}
@@ -0,0 +1,24 @@
+package wallet
+
+import "testing"
+
+func TestFromKeypair(t *testing.T) {
+	for _, seed := range []string{secpSeed, ed25519Seed} {
+		w := mustWallet(t, seed)
+		fromKeys, err := FromKeypair(w.PrivateKey, w.PublicKey)
+		if err != nil {
+			t.Fatalf("%s: %v", seed, err)
+		}
+		if fromKeys.ClassicAddress != w.ClassicAddress || fromKeys.Seed != "" {
+			t.Errorf("%s: got %+v", seed, fromKeys)
+		}
+	}
+
+	secp, ed := mustWallet(t, secpSeed), mustWallet(t, ed25519Seed)
+	if _, err := FromKeypair(secp.PrivateKey, ed.PublicKey); err == nil {
+		t.Error("accepted a public key of another private key")
+	}
+	if _, err := FromKeypair(ed.PrivateKey, secp.PublicKey); err == nil {
+		t.Error("accepted a public key of another private key")
+	}
+}
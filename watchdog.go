@@ -0,0 +1,194 @@
+package xrpl
+
+import (
+	"fmt"
+	"log"
+	"sync"
+	"time"
+)
+
+// IsSyncedServerState reports whether a rippled server_state value denotes a
+// server that is in sync with the network.
+// https://xrpl.org/docs/references/http-websocket-apis/api-conventions/rippled-server-states
+func IsSyncedServerState(state string) bool {
+	switch state {
+	case "full", "validating", "proposing":
+		return true
+	default:
+		return false
+	}
+}
+
+// health tracks ledger progress and sync state of the current connection.
+type health struct {
+	mutex           sync.Mutex
+	lastLedgerTime  time.Time
+	lastLedgerIndex uint64
+	serverState     string
+	healthy         bool
+	ledgerSignal    chan struct{} // closed and replaced on every new ledger
+}
+
+// reset starts a fresh grace period for a new connection.
+func (h *health) reset() {
+	h.mutex.Lock()
+	defer h.mutex.Unlock()
+	h.lastLedgerTime = time.Now()
+	h.lastLedgerIndex = 0
+	h.serverState = ""
+	h.healthy = true
+}
+
+// observeLedger records ledger progress. Ledgers at or below the last seen
+// index are ignored.
+func (h *health) observeLedger(ledgerIndex uint64) {
+	h.mutex.Lock()
+	defer h.mutex.Unlock()
+	if ledgerIndex > h.lastLedgerIndex {
+		h.lastLedgerIndex = ledgerIndex
+		h.lastLedgerTime = time.Now()
+		if h.ledgerSignal != nil {
+			close(h.ledgerSignal)
+			h.ledgerSignal = nil
+		}
+	}
+}
+
+// waitForLedger blocks until a ledger with index ledgerIndex or later has
+// been observed, or the timeout expires.
+func (h *health) waitForLedger(ledgerIndex uint64, timeout time.Duration) error {
+	timer := time.NewTimer(timeout)
+	defer timer.Stop()
+	for {
+		h.mutex.Lock()
+		if h.lastLedgerIndex >= ledgerIndex {
+			h.mutex.Unlock()
+			return nil
+		}
+		if h.ledgerSignal == nil {
+			h.ledgerSignal = make(chan struct{})
+		}
+		signal := h.ledgerSignal
+		h.mutex.Unlock()
+
+		select {
+		case <-signal:
+		case <-timer.C:
+			return fmt.Errorf("timed out waiting for ledger %d", ledgerIndex)
+		}
+	}
+}
+
+func (h *health) observeServerState(state string) {
+	h.mutex.Lock()
+	defer h.mutex.Unlock()
+	h.serverState = state
+}
+
+func (h *health) setHealthy(healthy bool) {
+	h.mutex.Lock()
+	defer h.mutex.Unlock()
+	h.healthy = healthy
+}
+
+// Healthy reports whether the current connection is healthy. A new
+// connection starts out healthy and is marked unhealthy when a watchdog check
+// fails.
+func (c *Client) Healthy() bool {
+	c.health.mutex.Lock()
+	defer c.health.mutex.Unlock()
+	return c.health.healthy
+}
+
+// ServerState returns the server_state reported by the server at the last
+// watchdog check.
+func (c *Client) ServerState() string {
+	c.health.mutex.Lock()
+	defer c.health.mutex.Unlock()
+	return c.health.serverState
+}
+
+// LastLedger returns the index of the most recent validated ledger seen on
+// the current connection, and the time it was first seen.
+func (c *Client) LastLedger() (uint64, time.Time) {
+	c.health.mutex.Lock()
+	defer c.health.mutex.Unlock()
+	return c.health.lastLedgerIndex, c.health.lastLedgerTime
+}
+
+// Failover switches to the next server in ClientConfig.FailoverURLs and
+// reconnects to it. After the last failover URL it wraps around to
+// ClientConfig.URL. Without failover URLs it reconnects to the same server.
+func (c *Client) Failover() error {
+	return c.reconnect(nil, true)
+}
+
+// nextURL moves on to the next server in ClientConfig.FailoverURLs.
+func (c *Client) nextURL() {
+	c.mutex.Lock()
+	c.urlIndex = (c.urlIndex + 1) % (len(c.config.FailoverURLs) + 1)
+	c.mutex.Unlock()
+}
+
+// Ledger progress watchdog. A websocket connection can keep answering pings
+// while the server behind it has lost sync. Every WatchdogInterval the
+// watchdog polls server_state, and fails over when the server is not synced
+// or no new validated ledger has been seen for LedgerTimeout.
+func (c *Client) watchdog() {
+	ticker := time.NewTicker(c.config.WatchdogInterval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-c.shutdown:
+			return
+		case <-ticker.C:
+			// Fail over only from the connection that was checked; it may
+			// have been replaced while the check was waiting for a reply.
+			done := c.currentDone()
+			if err := c.checkHealth(); err != nil {
+				c.health.setHealthy(false)
+				log.Println("WS watchdog:", c.URL(), err)
+				c.reconnect(done, true)
+				continue
+			}
+			c.health.setHealthy(true)
+		}
+	}
+}
+
+func (c *Client) checkHealth() error {
+	state, ledgerIndex, err := c.fetchServerState()
+	if err != nil {
+		return fmt.Errorf("server_state request failed: %w", err)
+	}
+	c.health.observeServerState(state)
+	c.health.observeLedger(ledgerIndex)
+
+	if !IsSyncedServerState(state) {
+		return fmt.Errorf("server is not synced: %s", state)
+	}
+	if _, t := c.LastLedger(); time.Since(t) > c.config.LedgerTimeout {
+		return fmt.Errorf("no ledger closed for %s", time.Since(t).Round(time.Second))
+	}
+	return nil
+}
+
+// fetchServerState returns server_state and the validated ledger index
+// reported by the server_state method.
+func (c *Client) fetchServerState() (string, uint64, error) {
+	var res struct {
+		Result struct {
+			State struct {
+				ServerState     string `json:"server_state"`
+				ValidatedLedger struct {
+					Seq uint64 `json:"seq"`
+				} `json:"validated_ledger"`
+			} `json:"state"`
+		} `json:"result"`
+	}
+	err := c.RequestInto(BaseRequest{"command": "server_state"}, &res)
+	if err != nil {
+		return "", 0, err
+	}
+	return res.Result.State.ServerState, res.Result.State.ValidatedLedger.Seq, nil
+}
@@ -0,0 +1,110 @@
+package xrpl
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+	"strings"
+
+	"github.com/xrpscan/xrpl-go/addresscodec"
+)
+
+// Address fields that may hold an X-address, and the tag field each one's
+// embedded tag is moved to.
+var xAddressFields = []struct {
+	address string
+	tag     string
+}{
+	{"Account", "SourceTag"},
+	{"Destination", "DestinationTag"},
+}
+
+// NormalizeXAddresses converts X-addresses in the Account and Destination
+// fields of tx to classic addresses, moving their tags to SourceTag and
+// DestinationTag. tx must be a pointer to a transaction struct such as
+// *models.TransactionPayment. An error is returned if an X-address is for
+// the wrong kind of network, if its tag conflicts with a tag already set on
+// tx, or if tx has no field for the tag.
+//
+// Tag fields may be integers or pointers to integers. An integer field of 0
+// counts as unset, as the models types omit it from JSON; use a pointer
+// field to set an explicit tag of 0, which then conflicts with any other
+// X-address tag.
+func (n Network) NormalizeXAddresses(tx interface{}) error {
+	v := reflect.ValueOf(tx)
+	if v.Kind() != reflect.Ptr || v.Elem().Kind() != reflect.Struct {
+		return errors.New("transaction must be a pointer to a struct")
+	}
+	v = v.Elem()
+
+	for _, f := range xAddressFields {
+		address := v.FieldByName(f.address)
+		if !address.IsValid() || address.Kind() != reflect.String {
+			continue
+		}
+		if !strings.HasPrefix(address.String(), "X") && !strings.HasPrefix(address.String(), "T") {
+			continue
+		}
+
+		classic, tag, hasTag, test, err := addresscodec.XAddressToClassicAddress(address.String())
+		if err != nil {
+			return fmt.Errorf("%s: %w", f.address, err)
+		}
+		if test != n.IsTest() {
+			return fmt.Errorf("%s: X-address is not for network %s", f.address, n.Name())
+		}
+		if hasTag {
+			tagField := v.FieldByName(f.tag)
+			if !tagField.IsValid() || !tagField.CanSet() {
+				return fmt.Errorf("%s: transaction has no %s for the X-address tag", f.address, f.tag)
+			}
+			if err := setTag(tagField, tag); err != nil {
+				return fmt.Errorf("%s: %s %w", f.address, f.tag, err)
+			}
+		}
+		address.SetString(classic)
+	}
+	return nil
+}
+
+// setTag sets an integer or pointer to integer tag field, unless it already
+// holds a different tag.
+func setTag(field reflect.Value, tag uint32) error {
+	if field.Kind() == reflect.Ptr {
+		if field.IsNil() {
+			value := reflect.New(field.Type().Elem())
+			if err := setTag(value.Elem(), tag); err != nil {
+				return err
+			}
+			field.Set(value)
+			return nil
+		}
+		field = field.Elem()
+		if current, ok := tagValue(field); ok && current != uint64(tag) {
+			return fmt.Errorf("%d conflicts with X-address tag %d", current, tag)
+		}
+	} else if current, ok := tagValue(field); ok && current != 0 && current != uint64(tag) {
+		return fmt.Errorf("%d conflicts with X-address tag %d", current, tag)
+	}
+
+	switch field.Kind() {
+	case reflect.Int, reflect.Int32, reflect.Int64:
+		field.SetInt(int64(tag))
+	case reflect.Uint, reflect.Uint32, reflect.Uint64:
+		field.SetUint(uint64(tag))
+	default:
+		return errors.New("has an unsupported type")
+	}
+	return nil
+}
+
+func tagValue(field reflect.Value) (uint64, bool) {
+	switch field.Kind() {
+	case reflect.Int, reflect.Int32, reflect.Int64:
+		return uint64(field.Int()), true
+	case reflect.Uint, reflect.Uint32, reflect.Uint64:
+		return field.Uint(), true
+	default:
+		return 0, false
+	}
+}